@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdAPIKey manages the guild's own Riot API key. When set, the
+// worker's refreshes for this guild's roster spend that key's rate
+// budget instead of the shared default key's.
+func cmdAPIKey(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		key, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingRiotAPIKey, "")
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			return ctx.Reply("このサーバー独自のAPIキーは設定されていません。共有キーを使用中です。`" +
+				ctx.Bot.cfg.Prefix + "apikey set <キー>` で設定できます。")
+		}
+		return ctx.Reply("このサーバー独自のAPIキーが設定されています。`" +
+			ctx.Bot.cfg.Prefix + "apikey clear` で共有キーに戻せます。")
+	}
+	switch ctx.Args[0] {
+	case "set":
+		if len(ctx.Args) < 2 || !strings.HasPrefix(ctx.Args[1], "RGAPI-") {
+			return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "apikey set RGAPI-...`")
+		}
+		// The message contains a secret: take it down before replying.
+		if err := ctx.Session.ChannelMessageDelete(ctx.Message.ChannelID, ctx.Message.ID); err != nil {
+			log.Printf("gateway: delete apikey message: %v", err)
+		}
+		if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingRiotAPIKey, ctx.Args[1]); err != nil {
+			return err
+		}
+		return ctx.Reply("このサーバー独自のAPIキーを設定しました。次回の更新から使用されます。")
+	case "clear":
+		if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingRiotAPIKey, ""); err != nil {
+			return err
+		}
+		return ctx.Reply("サーバー独自のAPIキーを削除しました。共有キーに戻ります。")
+	default:
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "apikey [set <キー>|clear]`")
+	}
+}
@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdAPIToken manages the guild's HTTP API tokens. Issuing the first
+// token locks this guild's API endpoints (and the event stream) behind
+// token auth; revoking them all opens the endpoints back up.
+func cmdAPIToken(ctx *Context) error {
+	usage := "使い方: `" + ctx.Bot.cfg.Prefix + "apitoken create <名前> [ranking|stats]...|revoke <名前>|list`"
+	if len(ctx.Args) == 0 {
+		return ctx.Reply(usage)
+	}
+	switch strings.ToLower(ctx.Args[0]) {
+	case "create":
+		if len(ctx.Args) < 2 {
+			return ctx.Reply(usage)
+		}
+		name := ctx.Args[1]
+		scopes := ctx.Args[2:]
+		if len(scopes) == 0 {
+			scopes = []string{storage.ScopeRanking, storage.ScopeStats}
+		}
+		for _, s := range scopes {
+			if !storage.ValidScope(s) {
+				return ctx.Reply("スコープは `ranking` または `stats` を指定してください。")
+			}
+		}
+		token, err := ctx.Bot.store.CreateAPIToken(ctx.GuildID(), name, scopes)
+		if err != nil {
+			return err
+		}
+		return ctx.Reply(fmt.Sprintf(
+			"🔑 APIトークン `%s` (%s) を発行しました:\n`%s`\nこのトークンは二度と表示されません。安全な場所に保存してください。",
+			name, strings.Join(scopes, ", "), token))
+	case "revoke":
+		if len(ctx.Args) < 2 {
+			return ctx.Reply(usage)
+		}
+		removed, err := ctx.Bot.store.RevokeAPIToken(ctx.GuildID(), ctx.Args[1])
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return ctx.Reply(fmt.Sprintf("`%s` というトークンはありません。`%sapitoken list` で確認してください。", ctx.Args[1], ctx.Bot.cfg.Prefix))
+		}
+		return ctx.Reply(fmt.Sprintf("APIトークン `%s` を失効させました。", ctx.Args[1]))
+	case "list":
+		tokens, err := ctx.Bot.store.APITokens(ctx.GuildID())
+		if err != nil {
+			return err
+		}
+		if len(tokens) == 0 {
+			return ctx.Reply("発行済みのAPIトークンはありません。APIは現在トークンなしで利用できます。")
+		}
+		var b strings.Builder
+		b.WriteString("**APIトークン**\n")
+		for _, t := range tokens {
+			fmt.Fprintf(&b, "・`%s` (%s) — %s発行\n",
+				t.Name, strings.Join(t.Scopes, ", "), t.CreatedAt.In(render.JST).Format("2006/01/02"))
+		}
+		return ctx.Reply(b.String())
+	default:
+		return ctx.Reply(usage)
+	}
+}
@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// banlistMinGames is the floor before a champion counts as a threat;
+// banlistPerPlayer caps how many bans are suggested per player.
+const (
+	banlistMinGames  = 3
+	banlistPerPlayer = 2
+)
+
+// cmdBanlist lists each registered player's most dangerous champions —
+// high recent games and winrate — for drafting in-house games.
+func cmdBanlist(ctx *Context) error {
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	to := time.Now()
+	from := to.AddDate(0, 0, -groupTierWindowDays)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**バン推奨リスト** (直近%d日)\n", groupTierWindowDays)
+	found := false
+	for i := range players {
+		threats, err := playerThreats(ctx, &players[i], from, to)
+		if err != nil {
+			return err
+		}
+		if len(threats) == 0 {
+			continue
+		}
+		found = true
+		parts := make([]string, 0, len(threats))
+		for _, c := range threats {
+			parts = append(parts, fmt.Sprintf("%s (%d戦 %.0f%%)", c.name, c.games, c.winrate()))
+		}
+		fmt.Fprintf(&b, "・%s: %s\n", players[i].RiotID(), strings.Join(parts, ", "))
+	}
+	if !found {
+		return ctx.Reply("バン推奨を出せるだけの試合データがまだありません。")
+	}
+	text := strings.TrimRight(b.String(), "\n")
+	if at, err := ctx.Bot.store.GuildMatchFetch(ctx.GuildID()); err == nil {
+		if footer := render.FreshnessFooter(time.Time{}, at); footer != "" {
+			text += "\n" + footer
+		}
+	}
+	return ctx.Reply(text)
+}
+
+// playerThreats ranks one player's recent champions by threat: games
+// played weighted by winrate.
+func playerThreats(ctx *Context, p *storage.Player, from, to time.Time) ([]*champStats, error) {
+	matches, err := ctx.Bot.store.MatchesForPlayer(p.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	agg := map[string]*champStats{}
+	for _, m := range matches {
+		if m.Info.QueueID != riotapi.QueueIDSolo {
+			continue
+		}
+		for _, part := range m.Info.Participants {
+			if part.PUUID != p.PUUID {
+				continue
+			}
+			c := agg[part.ChampionName]
+			if c == nil {
+				c = &champStats{name: part.ChampionName}
+				agg[part.ChampionName] = c
+			}
+			c.games++
+			if part.Win {
+				c.wins++
+			}
+			break
+		}
+	}
+	threats := make([]*champStats, 0, len(agg))
+	for _, c := range agg {
+		if c.games >= banlistMinGames && c.winrate() >= 50 {
+			threats = append(threats, c)
+		}
+	}
+	sort.SliceStable(threats, func(i, j int) bool {
+		return float64(threats[i].games)*threats[i].winrate() > float64(threats[j].games)*threats[j].winrate()
+	})
+	if len(threats) > banlistPerPlayer {
+		threats = threats[:banlistPerPlayer]
+	}
+	return threats, nil
+}
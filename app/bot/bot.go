@@ -0,0 +1,105 @@
+// Package bot is the Discord gateway process: it keeps the gateway
+// connection alive and answers chat commands, reading rank data from
+// the snapshots the worker maintains. Anything that needs heavy Riot
+// API traffic is enqueued as a task for the worker instead of being
+// done inline, so command handling never blocks the heartbeat.
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/config"
+	"github.com/keny-N/lol-ranking/app/ddragon"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// Bot is the gateway-side application state.
+type Bot struct {
+	cfg       *config.Config
+	store     *storage.Store
+	riot      *riotapi.Client
+	session   *discordgo.Session
+	router    *router
+	replies   *replyCache
+	champions *ddragon.Resolver
+}
+
+// New wires up a Bot; call Start to connect.
+func New(cfg *config.Config, store *storage.Store) (*Bot, error) {
+	session, err := discordgo.New("Bot " + cfg.DiscordToken)
+	if err != nil {
+		return nil, err
+	}
+	session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages | discordgo.IntentMessageContent
+
+	riot := riotapi.New(cfg.RiotAPIKey, cfg.Platform, cfg.Region)
+	if len(cfg.RegionFallbacks) > 0 {
+		riot = riot.WithRegionFallbacks(cfg.RegionFallbacks, func(from, to string) {
+			log.Printf("gateway: riot regional failover: %s -> %s", from, to)
+		})
+	}
+	b := &Bot{
+		cfg:       cfg,
+		store:     store,
+		riot:      riot,
+		session:   session,
+		router:    newRouter(),
+		replies:   newReplyCache(),
+		champions: ddragon.NewResolver(),
+	}
+	b.registerCommands()
+	b.registerModules()
+	session.AddHandler(b.onMessageCreate)
+	session.AddHandler(b.onGuildCreate)
+	session.AddHandler(b.onInteractionCreate)
+	return b, nil
+}
+
+// Start opens the gateway connection; it returns once connected.
+func (b *Bot) Start() error {
+	if err := b.session.Open(); err != nil {
+		return fmt.Errorf("open discord session: %w", err)
+	}
+	log.Println("gateway: connected to Discord")
+	b.syncApplicationCommands()
+	return nil
+}
+
+// Stop closes the gateway connection.
+func (b *Bot) Stop() error { return b.session.Close() }
+
+func (b *Bot) onMessageCreate(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if m.Author == nil || m.Author.Bot || m.GuildID == "" {
+		return
+	}
+	if !strings.HasPrefix(m.Content, b.cfg.Prefix) {
+		return
+	}
+	// Discord can redeliver events after a reconnect; never run the
+	// same command message twice.
+	first, err := b.store.MarkMessageProcessed(m.ID)
+	if err != nil {
+		log.Printf("gateway: dedupe check: %v", err)
+		return
+	}
+	if !first {
+		log.Printf("gateway: dropping redelivered message %s", m.ID)
+		return
+	}
+	ctx := &Context{Bot: b, Session: s, Message: m}
+	handled, err := b.router.dispatch(ctx, b.cfg.Prefix, m.Content)
+	if !handled {
+		return
+	}
+	if err != nil {
+		log.Printf("gateway: command error: %v", err)
+		if err := ctx.Reply(userMessage(err)); err != nil {
+			log.Printf("gateway: reply failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"strings"
+	"sync"
+)
+
+// replyCacheMax bounds the cache; exceeding it drops everything, which
+// is cheap and fine for a cache that repopulates on the next call.
+const replyCacheMax = 256
+
+// replyCache memoizes fully rendered command replies keyed by guild,
+// command and args, stamped with the guild's data version. A stale
+// stamp means the worker wrote new data (or settings changed) since the
+// reply was rendered, so the entry no longer matches.
+type replyCache struct {
+	mu      sync.Mutex
+	entries map[string]replyEntry
+}
+
+type replyEntry struct {
+	version string
+	content string
+}
+
+func newReplyCache() *replyCache {
+	return &replyCache{entries: make(map[string]replyEntry)}
+}
+
+// key builds the cache key for one invocation.
+func (rc *replyCache) key(guildID, command string, args []string) string {
+	return guildID + "|" + command + "|" + strings.Join(args, " ")
+}
+
+// get returns the cached reply if it was rendered at this data version.
+func (rc *replyCache) get(key, version string) (string, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	e, ok := rc.entries[key]
+	if !ok || e.version != version {
+		return "", false
+	}
+	return e.content, true
+}
+
+// put stores a rendered reply for a data version.
+func (rc *replyCache) put(key, version, content string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(rc.entries) >= replyCacheMax {
+		rc.entries = make(map[string]replyEntry)
+	}
+	rc.entries[key] = replyEntry{version: version, content: content}
+}
+
+// purge drops every cached reply, reporting how many were held.
+func (rc *replyCache) purge() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	n := len(rc.entries)
+	rc.entries = make(map[string]replyEntry)
+	return n
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// champsWindowDays is the aggregation window; champsTop caps the list.
+const (
+	champsWindowDays = 90
+	champsTop        = 10
+)
+
+// cmdChamps aggregates a player's champion pool from the cached match
+// history: games, winrate and average KDA per champion, most played
+// first. Everything comes from the worker's match cache, so the command
+// spends no Riot API budget.
+func cmdChamps(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		return errUsage("使い方: `" + ctx.Bot.cfg.Prefix + "champs <ゲーム名#タグ>`")
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return errUsage("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if errors.Is(err, storage.ErrNotRegistered) {
+		return ctx.Reply(fmt.Sprintf("**%s#%s** は登録されていません。", gameName, tagLine))
+	}
+	if err != nil {
+		return err
+	}
+
+	to := time.Now()
+	matches, err := ctx.Bot.store.MatchesForPlayer(player.ID, to.AddDate(0, 0, -champsWindowDays), to)
+	if err != nil {
+		return err
+	}
+	type record struct {
+		name                   string
+		games, wins            int
+		kills, deaths, assists int
+	}
+	agg := map[string]*record{}
+	for _, m := range matches {
+		if m.Info.QueueID != riotapi.QueueIDSolo && m.Info.QueueID != riotapi.QueueIDFlex {
+			continue
+		}
+		for _, p := range m.Info.Participants {
+			if p.PUUID != player.PUUID {
+				continue
+			}
+			r := agg[p.ChampionName]
+			if r == nil {
+				r = &record{name: p.ChampionName}
+				agg[p.ChampionName] = r
+			}
+			r.games++
+			if p.Win {
+				r.wins++
+			}
+			r.kills += p.Kills
+			r.deaths += p.Deaths
+			r.assists += p.Assists
+			break
+		}
+	}
+	if len(agg) == 0 {
+		return ctx.Reply(fmt.Sprintf("直近%d日で **%s** のランク戦の試合データがありません。", champsWindowDays, player.RiotID()))
+	}
+
+	records := make([]*record, 0, len(agg))
+	for _, r := range agg {
+		records = append(records, r)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].games != records[j].games {
+			return records[i].games > records[j].games
+		}
+		return records[i].wins > records[j].wins
+	})
+	if len(records) > champsTop {
+		records = records[:champsTop]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s のチャンピオン別戦績** (直近%d日)\n```\n", player.RiotID(), champsWindowDays)
+	for i, r := range records {
+		kda := float64(r.kills+r.assists) / float64(max(r.deaths, 1))
+		fmt.Fprintf(&b, "%2d. %-14s %2d試合 勝率%3.0f%% KDA %.2f\n",
+			i+1, r.name, r.games, float64(r.wins)/float64(r.games)*100, kda)
+	}
+	b.WriteString("```")
+	text := b.String()
+	if footer := playerFreshness(ctx, player); footer != "" {
+		text += "\n" + footer
+	}
+	return ctx.Reply(text)
+}
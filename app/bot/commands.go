@@ -0,0 +1,1020 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/feature"
+	"github.com/keny-N/lol-ranking/app/i18n"
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/sched"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+func (b *Bot) registerCommands() {
+	b.router.register(&Command{
+		Name:     "help",
+		Usage:    "help [コマンド名]",
+		Help:     "コマンド一覧、または各コマンドの詳細を表示します",
+		Examples: []string{"help", "help ranking"},
+		Handler:  cmdHelp,
+	})
+	b.router.register(&Command{
+		Name:      "ranking",
+		Usage:     "ranking [solo|flex] [role:<ロール>] [sort:<基準>] [--compact|--detailed]",
+		Help:      "登録プレイヤーのランク戦ランキングを表示します",
+		Detail:    "登録プレイヤーの最新スナップショットから順位表を作ります。既定はソロランクで、flex を付けるとフレックスになります。role: でメインロール別に絞り込め、sort:winrate / sort:consistency で並び替えられます。",
+		Examples:  []string{"ranking", "ranking flex", "ranking role:jungle", "ranking sort:winrate", "ranking --compact"},
+		Cacheable: true,
+		Handler:   cmdRanking,
+	})
+	b.router.register(&Command{
+		Name:     "rank",
+		Usage:    "rank <ゲーム名#タグ> [solo|flex]",
+		Help:     "指定プレイヤーの現在のランクを表示します",
+		Detail:   "指定プレイヤーの現在のランクを表示します。既定はソロランクで、flex を付けるとフレックスになります。",
+		Examples: []string{"rank Faker#KR1", "rank Faker#KR1 flex"},
+		Handler:  cmdRank,
+	})
+	b.router.register(&Command{
+		Name:      "rankhistory",
+		Usage:     "rankhistory <ゲーム名#タグ> [solo|flex]",
+		Help:      "プレイヤーの日次ランク推移を表示します",
+		Detail:    "キューごとに記録されたスナップショットから日次の到達ランクを一覧にします。既定はソロランクです。",
+		Examples:  []string{"rankhistory Faker#KR1", "rankhistory Faker#KR1 flex"},
+		Cacheable: true,
+		Handler:   cmdRankHistory,
+	})
+	b.router.register(&Command{
+		Name:      "compareseasons",
+		Usage:     "compareseasons <ゲーム名#タグ>",
+		Help:      "今シーズンと前シーズンのLP推移を比較します",
+		Detail:    "シーズン開始からの経過日数を揃えて、今シーズンの日次ランクを前シーズンの同時期と並べます。前シーズンより先行しているか遅れているかの判定付きです。",
+		Examples:  []string{"compareseasons Faker#KR1"},
+		Cacheable: true,
+		Handler:   cmdCompareSeasons,
+	})
+	b.router.register(&Command{
+		Name:      "daystats",
+		Usage:     "daystats <ゲーム名#タグ>|all [YYYYMMDD]",
+		Help:      "指定日のランク戦績（勝敗と各試合のKDA）を表示します",
+		Detail:    "日付を省略すると今日の戦績と、直近2週間から選べる日付メニューを表示します。`all` で登録プレイヤー全員の試合数順まとめを表示します。",
+		Examples:  []string{"daystats Faker#KR1", "daystats Faker#KR1 20260801", "daystats all"},
+		Cacheable: true,
+		Handler:   cmdDaystats,
+	})
+	b.router.register(&Command{
+		Name:      "weekstats",
+		Usage:     "weekstats [ゲーム名#タグ]",
+		Help:      "直近7日間の勝敗・勝率・LP変動をまとめます",
+		Detail:    "キャッシュ済みの試合とスナップショットから、登録プレイヤーごとの週間成績を試合数順に表示します。Riot IDを指定するとそのプレイヤーだけに絞り込めます。",
+		Examples:  []string{"weekstats", "weekstats Faker#KR1"},
+		Cacheable: true,
+		Handler:   cmdWeekstats,
+	})
+	b.router.register(&Command{
+		Name:      "monthstats",
+		Usage:     "monthstats [ゲーム名#タグ]",
+		Help:      "直近30日間の勝敗・勝率・LP変動をまとめます",
+		Detail:    "キャッシュ済みの試合とスナップショットから、登録プレイヤーごとの月間成績を試合数順に表示します。Riot IDを指定するとそのプレイヤーだけに絞り込めます。",
+		Examples:  []string{"monthstats", "monthstats Faker#KR1"},
+		Cacheable: true,
+		Handler:   cmdMonthstats,
+	})
+	b.router.register(&Command{
+		Name:      "sessions",
+		Usage:     "sessions <ゲーム名#タグ>",
+		Help:      "プレイセッション（連続プレイのまとまり）の傾向を表示します",
+		Detail:    "キャッシュ済みの試合を1時間以上の間隔で区切ってセッションにまとめ、回数・平均時間・最長セッションを集計します。",
+		Examples:  []string{"sessions Faker#KR1"},
+		Cacheable: true,
+		Handler:   cmdSessions,
+	})
+	b.router.register(&Command{
+		Name:      "grouptier",
+		Usage:     "grouptier",
+		Help:      "登録プレイヤー全体のチャンピオン使用率と勝率を集計します",
+		Cacheable: true,
+		Handler:   cmdGroupTier,
+	})
+	b.router.register(&Command{
+		Name:     "intcheck",
+		Usage:    "intcheck [日数|on|off]",
+		Help:     "デス数の多さをネタにするお遊びランキングです（ギルドごとにオプトイン）",
+		Examples: []string{"intcheck", "intcheck 14", "intcheck on"},
+		Handler:  cmdIntCheck,
+	})
+	b.router.register(&Command{
+		Name:      "banlist",
+		Usage:     "banlist",
+		Help:      "各プレイヤーの危険チャンピオン（バン候補）を一覧にします",
+		Detail:    "登録プレイヤーごとに直近の試合数と勝率が高いチャンピオンを挙げます。身内戦のドラフトのお供に。",
+		Cacheable: true,
+		Handler:   cmdBanlist,
+	})
+	b.router.register(&Command{
+		Name:      "nemesis",
+		Usage:     "nemesis <ゲーム名#タグ>",
+		Help:      "プレイヤーが最もよく負けている敵チャンピオンを表示します",
+		Detail:    "キャッシュ済みの試合から、対戦して負けた回数が多い敵チャンピオンのトップ3を集計します。",
+		Examples:  []string{"nemesis Faker#KR1"},
+		Cacheable: true,
+		Handler:   cmdNemesis,
+	})
+	b.router.register(&Command{
+		Name:      "champs",
+		Usage:     "champs <ゲーム名#タグ>",
+		Help:      "プレイヤーのチャンピオン別戦績を表示します",
+		Detail:    "キャッシュ済みのランク戦の試合から、チャンピオンごとの試合数・勝率・平均KDAを試合数順に集計します。",
+		Examples:  []string{"champs Faker#KR1"},
+		Cacheable: true,
+		Handler:   cmdChamps,
+	})
+	b.router.register(&Command{
+		Name:     "mastery",
+		Usage:    "mastery <ゲーム名#タグ>",
+		Help:     "プレイヤーのチャンピオンマスタリー上位を表示します",
+		Detail:   "Champion Mastery APIからマスタリー上位5体のレベルとポイントを取得します。未登録のプレイヤーも指定できます。",
+		Examples: []string{"mastery Faker#KR1"},
+		Handler:  cmdMastery,
+	})
+	b.router.register(&Command{
+		Name:     "match",
+		Usage:    "match <試合ID|latest ゲーム名#タグ>",
+		Help:     "試合のスコアボードを表示します",
+		Examples: []string{"match JP1_123456789", "match latest Faker#KR1"},
+		Handler:  cmdMatch,
+	})
+	b.router.register(&Command{
+		Name:      "race",
+		Usage:     "race <ティア> [ディビジョン]",
+		Help:      "指定ランク到達レースの途中経過を表示します",
+		Examples:  []string{"race diamond 4", "race master"},
+		Cacheable: true,
+		Handler:   cmdRace,
+	})
+	b.router.register(&Command{
+		Name:      "raceday",
+		Usage:     "raceday [add <YYYYMMDD>|remove <YYYYMMDD>|list]",
+		Help:      "LPレースデーを設定します",
+		Detail:    "レースデーの朝5時にアナウンスチャンネルへスレッドが開き、更新のたびにLPの増減が実況されます。次のリセットで最終順位を発表してスレッドを閉じます。",
+		Examples:  []string{"raceday add 20260905", "raceday list"},
+		AdminOnly: true,
+		Handler:   cmdRaceday,
+	})
+	b.router.register(&Command{
+		Name:     "scout",
+		Usage:    "scout <ゲーム名#タグ>",
+		Help:     "未登録プレイヤーのランク・最近のチャンピオンを素早く調べます",
+		Detail:   "チャンピオン選択中の偵察向け。結果は10分間キャッシュされ、同じ相手の再検索は即座に返ります。",
+		Examples: []string{"scout Hide on bush#KR1"},
+		Feature:  feature.Scout,
+		Handler:  cmdScout,
+	})
+	b.router.register(&Command{
+		Name:     "live",
+		Usage:    "live [ゲーム名#タグ]",
+		Help:     "進行中の試合の10人全員のランクとチーム平均を表示します",
+		Detail:   "引数なしで自分のリンク済みアカウントを観戦します。⚠ は各チームで最もランクが高いプレイヤーです。",
+		Examples: []string{"live", "live Faker#KR1"},
+		Feature:  feature.Live,
+		Handler:  cmdLive,
+	})
+	b.router.register(&Command{
+		Name:      "share",
+		Usage:     "share ranking [日数]",
+		Help:      "ランキングの閲覧専用公開リンクを発行します",
+		Detail:    "署名付き・期限付きのURLを発行します。Discord外への共有用で、期限が切れると無効になります。",
+		Examples:  []string{"share ranking", "share ranking 3"},
+		AdminOnly: true,
+		Handler:   cmdShare,
+	})
+	b.router.register(&Command{
+		Name:     "groupactivity",
+		Usage:    "groupactivity",
+		Help:     "直近90日のグループのランク戦プレイ量をグラフ画像で表示します",
+		Examples: []string{"groupactivity"},
+		Handler:  cmdGroupActivity,
+	})
+	b.router.register(&Command{
+		Name:     "grouplp",
+		Usage:    "grouplp [週数]",
+		Help:     "グループ全員の合計LP換算スコアの推移をグラフ画像で表示します",
+		Detail:   "登録プレイヤー全員の日次スナップショットをLP換算で合計し、シーズンを通したグループ全体の成長を折れ線グラフにします。",
+		Examples: []string{"grouplp", "grouplp 8"},
+		Handler:  cmdGroupLP,
+	})
+	b.router.register(&Command{
+		Name:     "globalranking",
+		Usage:    "globalranking [on|off]",
+		Help:     "参加サーバー全体を合算したランキングを表示します（オプトイン制）",
+		Detail:   "`on` で自サーバーのロスターを公開します（管理者のみ）。同一アカウントはPUUIDで重複排除され、所属サーバー名付きで表示されます。",
+		Examples: []string{"globalranking", "globalranking on"},
+		Handler:  cmdGlobalRanking,
+	})
+	b.router.register(&Command{
+		Name:     "powerranking",
+		Usage:    "powerranking [set <tier> <winrate> <games>]",
+		Help:     "重み付きの総合スコアでパワーランキングを表示します",
+		Detail:   "ティアポイント・直近勝率・直近試合数を重み付けした総合スコアの順位表です。`set` で重みを変更できます（管理者のみ）。",
+		Examples: []string{"powerranking", "powerranking set 1 0.5 0.2"},
+		Handler:  cmdPowerRanking,
+	})
+	b.router.register(&Command{
+		Name:     "profile",
+		Usage:    "profile [ゲーム名#タグ|@ユーザー]",
+		Help:     "プレイヤーのプロフィールカードを表示します",
+		Detail:   "現在のランク・自己ベスト・得意チャンピオン・直近の戦績をまとめた埋め込みです。ボタンからランク履歴と活動グラフに移動できます。",
+		Examples: []string{"profile", "profile Hide on bush#KR1", "profile @user"},
+		Handler:  cmdProfile,
+	})
+	b.router.register(&Command{
+		Name:     "me",
+		Usage:    "me [link <ゲーム名#タグ> [プラットフォーム]]",
+		Help:     "自分のリンク済みアカウント一覧とベストアカウントを表示します",
+		Detail:   "`link` で複数リージョンのアカウントを自分にひもづけられます。ランキングでは一人につきベストアカウントのみ掲載されます。",
+		Examples: []string{"me", "me link Faker#KR1 kr"},
+		Handler:  cmdMe,
+	})
+	b.router.register(&Command{
+		Name:     "goal",
+		Usage:    "goal [ティア] [ディビジョン]",
+		Help:     "今シーズンの目標ランクを設定・確認します",
+		Detail:   "リンク済みアカウントにシーズン目標を設定します。達成するとワーカーがチャンネルでお祝いします。",
+		Examples: []string{"goal diamond 4", "goal"},
+		Handler:  cmdGoal,
+	})
+	b.router.register(&Command{
+		Name:     "canplaywith",
+		Usage:    "canplaywith <ゲーム名#タグ> <ゲーム名#タグ>",
+		Help:     "2人がソロランクでデュオ可能かを判定します",
+		Examples: []string{"canplaywith Faker#KR1 Oner#KR1"},
+		Handler:  cmdCanPlayWith,
+	})
+	b.router.register(&Command{
+		Name:     "flexnight",
+		Usage:    "flexnight [YYYYMMDD] [HH:MM]",
+		Help:     "プレイセッションの参加者募集を開始します",
+		Detail:   "参加ボタン付きの募集を投稿し、" + strconv.Itoa(flexNightQuorum) + "人集まると開催決定。開催時刻になるとワーカーが参加者にメンションでお知らせします。",
+		Examples: []string{"flexnight", "flexnight 20260830 20:00"},
+		Handler:  cmdFlexNight,
+	})
+	b.router.register(&Command{
+		Name:     "add",
+		Usage:    "add <ゲーム名#タグ|op.ggマルチサーチURL|カンマ区切りリスト>",
+		Help:     "プレイヤーをランキングに登録します",
+		Examples: []string{"add Faker#KR1", "add https://www.op.gg/multisearch/jp?summoners=Faker%23KR1,Oner%23KR1"},
+		Handler:  cmdAdd,
+	})
+	b.router.register(&Command{
+		Name:     "remove",
+		Usage:    "remove <ゲーム名#タグ>",
+		Help:     "プレイヤーをランキングから削除します",
+		Examples: []string{"remove Faker#KR1"},
+		Handler:  cmdRemove,
+	})
+	b.router.register(&Command{
+		Name:      "scan",
+		Usage:     "scan",
+		Help:      "メンバーの表示名からRiot IDらしき候補を探して提案します",
+		Detail:    "ニックネームやユーザー名に含まれる `ゲーム名#タグ` のパターンを探し、未登録のものを確認ボタン付きで提案します。",
+		AdminOnly: true,
+		Handler:   cmdScan,
+	})
+	b.router.register(&Command{
+		Name:      "import",
+		Usage:     "import <Riot IDリスト|CSV/JSON添付>",
+		Help:      "複数プレイヤーを一括登録します",
+		Detail:    "改行・カンマ区切りのRiot IDリスト、またはCSV/JSONファイルの添付を受け付けます。1件ずつ検証し、結果をまとめて報告します。",
+		Examples:  []string{"import Faker#KR1, Oner#KR1", "import (CSVファイルを添付)"},
+		AdminOnly: true,
+		// A full batch is importMax entries at several throttled API
+		// calls each, so it needs more than the default budget.
+		Budget:  5 * time.Minute,
+		Handler: cmdImport,
+	})
+	b.router.register(&Command{
+		Name:     "validate",
+		Usage:    "validate",
+		Help:     "ロスターの各アカウントの状態（消失・非アクティブ・休止）を診断します",
+		Detail:   "ワーカーの定期更新が検出したアカウント状態を一覧にします。404が続くアカウントは移管・BAN・リネームの可能性があります。",
+		Examples: []string{"validate"},
+		Handler:  cmdValidate,
+	})
+	b.router.register(&Command{
+		Name:     "pause",
+		Usage:    "pause <ゲーム名#タグ> [YYYYMMDD]",
+		Help:     "プレイヤーを休止扱いにして更新対象から外します",
+		Examples: []string{"pause Faker#KR1", "pause Faker#KR1 20260930"},
+		Handler:  cmdPause,
+	})
+	b.router.register(&Command{
+		Name:    "unpause",
+		Usage:   "unpause <ゲーム名#タグ>",
+		Help:    "休止中のプレイヤーを再開します",
+		Handler: cmdUnpause,
+	})
+	b.router.register(&Command{
+		Name:      "priority",
+		Usage:     "priority [<ゲーム名#タグ> [on|off]]",
+		Help:      "プレイヤーを優先更新（約10分ごと）にします",
+		Detail:    "昇格チャレンジ中などに指定すると、通常の1時間周期の代わりに約10分ごとにランクを更新します。引数なしで優先更新中の一覧を表示します。",
+		Examples:  []string{"priority", "priority Faker#KR1 on", "priority Faker#KR1 off"},
+		AdminOnly: true,
+		Handler:   cmdPriority,
+	})
+	b.router.register(&Command{
+		Name:     "mentions",
+		Usage:    "mentions [on|off]",
+		Help:     "まとめ投稿や昇格通知で自分をメンションするか設定します",
+		Examples: []string{"mentions", "mentions off"},
+		Handler:  cmdMentions,
+	})
+	b.router.register(&Command{
+		Name:     "remind",
+		Usage:    "remind me daily <HH:MM> <メッセージ>|list|delete <番号>",
+		Help:     "毎日決まった時刻にDMで届くリマインダーを設定します",
+		Examples: []string{"remind me daily 20:00 ランク3戦やる", "remind list", "remind delete 1"},
+		Handler:  cmdRemind,
+	})
+	b.router.register(&Command{
+		Name:     "schedule",
+		Usage:    "schedule daily [cron式]",
+		Help:     "日次ランキング投稿の時刻をcron式で設定・確認します",
+		Examples: []string{"schedule daily", "schedule daily 0 21 * * *"},
+		Handler:  cmdSchedule,
+	})
+	b.router.register(&Command{
+		Name:      "preview",
+		Usage:     "preview daily|recap",
+		Help:      "定期投稿の内容を今すぐこのチャンネルで確認します",
+		AdminOnly: true,
+		Handler:   cmdPreview,
+	})
+	b.router.register(&Command{
+		Name:      "post",
+		Usage:     "post daily|recap",
+		Help:      "定期投稿を今すぐ投稿チャンネルに送信します",
+		AdminOnly: true,
+		Handler:   cmdPost,
+	})
+	b.router.register(&Command{
+		Name:      "config",
+		Usage:     "config export|import <JSON>",
+		Help:      "ギルド設定とロスターをJSONで書き出し・取り込みします",
+		AdminOnly: true,
+		Handler:   cmdConfig,
+	})
+	b.router.register(&Command{
+		Name:    "format",
+		Usage:   "format [compact|detailed]",
+		Help:    "ランキング等の出力形式の既定値を設定します",
+		Handler: cmdFormat,
+	})
+	b.router.register(&Command{
+		Name:    "storage",
+		Usage:   "storage",
+		Help:    "データベースのサイズと保存件数を表示します",
+		Handler: cmdStorage,
+	})
+	b.router.register(&Command{
+		Name:     "setchannel",
+		Usage:    "setchannel [daily|recap|promotions|live]",
+		Help:     "このチャンネルを投稿先に設定します（種類別の指定も可能）",
+		Examples: []string{"setchannel", "setchannel recap"},
+		Handler:  cmdSetChannel,
+	})
+	b.router.register(&Command{
+		Name:      "apikey",
+		Usage:     "apikey [set <キー>|clear]",
+		Help:      "このサーバー専用のRiot APIキーを設定します",
+		Detail:    "設定すると、このサーバーのロスター更新は共有キーではなく専用キーのレート制限枠を使用します。キーを含むメッセージは設定後に削除されます。",
+		Examples:  []string{"apikey", "apikey set RGAPI-xxxxxxxx", "apikey clear"},
+		AdminOnly: true,
+		Handler:   cmdAPIKey,
+	})
+	b.router.register(&Command{
+		Name:      "apitoken",
+		Usage:     "apitoken create <名前> [ranking|stats]...|revoke <名前>|list",
+		Help:      "HTTP API用のアクセストークンを管理します",
+		Detail:    "最初のトークンを発行すると、このサーバーのAPIエンドポイントはトークン認証が必須になります。スコープを省略すると ranking と stats の両方が付与されます。",
+		Examples:  []string{"apitoken create obs ranking", "apitoken revoke obs", "apitoken list"},
+		AdminOnly: true,
+		Handler:   cmdAPIToken,
+	})
+	b.router.register(&Command{
+		Name:         "maintenance",
+		Usage:        "maintenance [on|off]",
+		Help:         "インスタンス全体のメンテナンスモードを切り替えます",
+		Detail:       "オンの間は定期処理が停止し、運用者以外のコマンドにはメンテナンス中の案内を返します。",
+		Examples:     []string{"maintenance", "maintenance on"},
+		OperatorOnly: true,
+		Handler:      cmdMaintenance,
+	})
+	b.router.register(&Command{
+		Name:         "ops",
+		Usage:        "ops digest",
+		Help:         "全サーバー横断のボット稼働状況まとめを表示します",
+		Detail:       "直近24時間の規模・ストレージ増加・更新の滞り・失敗タスク・アクティブなサーバーをまとめます。",
+		Examples:     []string{"ops digest"},
+		OperatorOnly: true,
+		Handler:      cmdOps,
+	})
+	b.router.register(&Command{
+		Name:         "cachepurge",
+		Usage:        "cachepurge",
+		Help:         "ゲートウェイのメモリ内キャッシュを削除します",
+		OperatorOnly: true,
+		Handler:      cmdCachePurge,
+	})
+	b.router.register(&Command{
+		Name:         "resync",
+		Usage:        "resync",
+		Help:         "スラッシュコマンドをDiscordに再登録します",
+		OperatorOnly: true,
+		Handler:      cmdResync,
+	})
+}
+
+func cmdHelp(ctx *Context) error {
+	if len(ctx.Args) >= 1 {
+		return replyCommandHelp(ctx, strings.ToLower(ctx.Args[0]))
+	}
+	var b strings.Builder
+	b.WriteString("**コマンド一覧**\n")
+	for _, name := range ctx.Bot.router.order {
+		cmd := ctx.Bot.router.commands[name]
+		fmt.Fprintf(&b, "`%s%s` — %s\n", ctx.Bot.cfg.Prefix, cmd.Usage, cmd.Help)
+	}
+	fmt.Fprintf(&b, "\n`%shelp <コマンド名>` で各コマンドの詳しい使い方を表示します。", ctx.Bot.cfg.Prefix)
+	return ctx.Reply(b.String())
+}
+
+// replyCommandHelp prints one command's detailed usage, built from the
+// metadata registered in the router.
+func replyCommandHelp(ctx *Context, name string) error {
+	cmd, ok := ctx.Bot.router.commands[strings.TrimPrefix(name, ctx.Bot.cfg.Prefix)]
+	if !ok {
+		return ctx.Reply(fmt.Sprintf("`%s` というコマンドはありません。`%shelp` で一覧を確認してください。", name, ctx.Bot.cfg.Prefix))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s%s**\n", ctx.Bot.cfg.Prefix, cmd.Name)
+	detail := cmd.Detail
+	if detail == "" {
+		detail = cmd.Help
+	}
+	b.WriteString(detail + "\n")
+	fmt.Fprintf(&b, "使い方: `%s%s`\n", ctx.Bot.cfg.Prefix, cmd.Usage)
+	if cmd.AdminOnly {
+		b.WriteString("※ サーバー管理者のみ使用できます。\n")
+	}
+	if cmd.OperatorOnly {
+		b.WriteString("※ インスタンスの運用者のみ使用できます。\n")
+	}
+	if len(cmd.Examples) > 0 {
+		b.WriteString("例:\n")
+		for _, ex := range cmd.Examples {
+			fmt.Fprintf(&b, "・`%s%s`\n", ctx.Bot.cfg.Prefix, ex)
+		}
+	}
+	return ctx.Reply(b.String())
+}
+
+func cmdRanking(ctx *Context) error {
+	role, sortKey := "", ""
+	queue := riotapi.QueueSolo
+	for _, arg := range ctx.Args {
+		if q, ok := queueByName[strings.ToLower(arg)]; ok {
+			queue = q
+		}
+		if v, ok := strings.CutPrefix(arg, "role:"); ok {
+			role = normalizeRole(v)
+			if role == "" {
+				return ctx.Reply("ロールは top / jungle / mid / bot / support のいずれかで指定してください。")
+			}
+		}
+		if v, ok := strings.CutPrefix(arg, "sort:"); ok {
+			sortKey = v
+		}
+	}
+	format, err := outputFormat(ctx)
+	if err != nil {
+		return err
+	}
+	loc, err := guildLocale(ctx)
+	if err != nil {
+		return err
+	}
+	players, err := rankingData(ctx.Bot.store, ctx.GuildID(), queue)
+	if err != nil {
+		return err
+	}
+	switch sortKey {
+	case "":
+	case "consistency":
+		return consistencyRanking(ctx, players)
+	case "winrate":
+		return winrateRanking(ctx, players, loc)
+	default:
+		return ctx.Reply("並び替えに使えるのは `sort:consistency` と `sort:winrate` です。")
+	}
+	title := render.SoloRankingTitle
+	if queue == riotapi.QueueFlex {
+		title = render.FlexRankingTitle
+	}
+	if role != "" {
+		filtered := players[:0]
+		for i := range players {
+			main, err := mainRole(ctx.Bot.store, &players[i].Player)
+			if err != nil {
+				return err
+			}
+			if main == role {
+				filtered = append(filtered, players[i])
+			}
+		}
+		if len(filtered) == 0 {
+			return ctx.Reply(fmt.Sprintf("メインロールが%sのプレイヤーは見つかりませんでした。", roleLabels[role]))
+		}
+		return ctx.Reply(render.Ranking(filtered, roleLabels[role]+title, format, loc))
+	}
+	text := render.Ranking(players, title, format, loc)
+	if format == render.Detailed && len(players) > 0 {
+		embed, pages := rankingEmbed(players, title, loc, 0)
+		if pages > 1 && ctx.send == nil {
+			return sendPagedRanking(ctx, embed, queue, text)
+		}
+		return ctx.ReplyEmbed(embed, text)
+	}
+	return ctx.Reply(text)
+}
+
+// winrateRanking reorders the leaderboard by season winrate instead of
+// tier/LP. Players without ranked games this season are left out.
+func winrateRanking(ctx *Context, players []storage.RankedPlayer, loc i18n.Locale) error {
+	var rated []storage.RankedPlayer
+	for _, p := range players {
+		if p.Wins+p.Losses > 0 {
+			rated = append(rated, p)
+		}
+	}
+	if len(rated) == 0 {
+		return ctx.Reply("今シーズンのランク戦績があるプレイヤーがいません。")
+	}
+	winrate := func(p *storage.RankedPlayer) float64 {
+		return float64(p.Wins) / float64(p.Wins+p.Losses)
+	}
+	sort.SliceStable(rated, func(i, j int) bool {
+		wi, wj := winrate(&rated[i]), winrate(&rated[j])
+		if wi != wj {
+			return wi > wj
+		}
+		// At the same winrate, more games is the stronger record.
+		return rated[i].Wins+rated[i].Losses > rated[j].Wins+rated[j].Losses
+	})
+	var b strings.Builder
+	b.WriteString("**勝率ランキング**\n```\n")
+	for i := range rated {
+		p := &rated[i]
+		fmt.Fprintf(&b, "%2d. %-24s 勝率%.1f%% (%s) %s\n",
+			i+1, p.RiotID(), winrate(p)*100, loc.WinLoss(p.Wins, p.Losses),
+			rank.Format(p.Tier, p.Division, p.LP))
+	}
+	b.WriteString("```")
+	return ctx.Reply(b.String())
+}
+
+// guildLocale resolves the guild's display locale setting.
+func guildLocale(ctx *Context) (i18n.Locale, error) {
+	stored, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingLocale, "ja")
+	if err != nil {
+		return i18n.Japanese, err
+	}
+	return i18n.Parse(stored), nil
+}
+
+// outputFormat resolves the output format for a command invocation:
+// an explicit --compact/--detailed flag wins over the guild default.
+func outputFormat(ctx *Context) (render.Format, error) {
+	for _, arg := range ctx.Args {
+		switch arg {
+		case "--compact":
+			return render.Compact, nil
+		case "--detailed":
+			return render.Detailed, nil
+		}
+	}
+	stored, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingOutputFormat, "detailed")
+	if err != nil {
+		return render.Detailed, err
+	}
+	format, _ := render.ParseFormat(stored)
+	return format, nil
+}
+
+func cmdFormat(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		stored, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingOutputFormat, "detailed")
+		if err != nil {
+			return err
+		}
+		return ctx.Reply(fmt.Sprintf("現在の出力形式: `%s`", stored))
+	}
+	if _, ok := render.ParseFormat(ctx.Args[0]); !ok {
+		return ctx.Reply("出力形式は `compact` または `detailed` を指定してください。")
+	}
+	if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingOutputFormat, ctx.Args[0]); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("出力形式を `%s` に設定しました。", ctx.Args[0]))
+}
+
+func cmdRank(ctx *Context) error {
+	args := ctx.Args
+	queue := riotapi.QueueSolo
+	queueLabel := "ソロ"
+	if len(args) > 0 {
+		if q, ok := queueByName[strings.ToLower(args[len(args)-1])]; ok {
+			queue = q
+			if q == riotapi.QueueFlex {
+				queueLabel = "フレックス"
+			}
+			args = args[:len(args)-1]
+		}
+	}
+	if len(args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "rank <ゲーム名#タグ> [solo|flex]`")
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(args, " "))
+	if !ok {
+		return ctx.Reply("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+
+	// Registered players already have a summoner ID and platform on
+	// file; anyone else needs the full account → platform → summoner
+	// resolution first so mixed-region lookups hit the right host.
+	var summonerID string
+	riot := ctx.Bot.riot
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	switch {
+	case err == nil:
+		summonerID = player.SummonerID
+		gameName, tagLine = player.GameName, player.TagLine
+		riot = riot.ForPlatform(player.Platform)
+	case err == storage.ErrNotRegistered:
+		account, err := ctx.Bot.riot.AccountByRiotID(gameName, tagLine)
+		if err != nil {
+			return err
+		}
+		platform, err := detectPlatform(ctx, account.PUUID)
+		if err != nil {
+			return err
+		}
+		riot = riot.ForPlatform(platform)
+		summoner, err := riot.SummonerByPUUID(account.PUUID)
+		if err != nil {
+			return err
+		}
+		summonerID = summoner.ID
+		gameName, tagLine = account.GameName, account.TagLine
+	default:
+		return err
+	}
+
+	entries, err := riot.LeagueEntriesBySummonerID(summonerID)
+	if err != nil {
+		// An outage should not leave registered players with nothing:
+		// serve the last stored snapshot, clearly marked as stale.
+		// Definitive answers (unknown ID, bad key) still surface.
+		if player != nil && !errors.Is(err, riotapi.ErrNotFound) && !errors.Is(err, riotapi.ErrForbidden) {
+			if text, ok, snapErr := staleRankReply(ctx, player, queue, queueLabel); snapErr == nil && ok {
+				return ctx.Reply(text)
+			}
+		}
+		return err
+	}
+	for _, e := range entries {
+		if e.QueueType == queue {
+			if player != nil {
+				if err := ctx.Bot.store.SaveSnapshot(&storage.Snapshot{
+					PlayerID: player.ID, Queue: e.QueueType,
+					Tier: e.Tier, Division: e.Rank, LP: e.LeaguePoints,
+					Wins: e.Wins, Losses: e.Losses,
+				}); err != nil {
+					return err
+				}
+			}
+			reply := fmt.Sprintf("**%s#%s**: %s",
+				gameName, tagLine, rank.Format(e.Tier, e.Rank, e.LeaguePoints))
+			if queue == riotapi.QueueFlex {
+				reply = fmt.Sprintf("**%s#%s** (フレックス): %s",
+					gameName, tagLine, rank.Format(e.Tier, e.Rank, e.LeaguePoints))
+			}
+			if top, ok := rank.Percentile(e.Tier, e.Rank); ok {
+				reply += fmt.Sprintf(" — 全プレイヤーの上位約%.1f%%", top)
+			}
+			// The group comparison ranks solo snapshots, so it only
+			// makes sense next to a solo result.
+			if player != nil && queue == riotapi.QueueSolo {
+				cmp, err := groupComparison(ctx, player)
+				if err != nil {
+					return err
+				}
+				if cmp != "" {
+					reply += "\n" + cmp
+				}
+			}
+			return ctx.Reply(reply)
+		}
+	}
+	return ctx.Reply(fmt.Sprintf("**%s#%s**: 今シーズンの%sランク戦績がありません。", gameName, tagLine, queueLabel))
+}
+
+// staleRankReply renders the last stored snapshot for a registered
+// player, marked with when it was taken; ok is false when no snapshot
+// exists to fall back on.
+func staleRankReply(ctx *Context, player *storage.Player, queue, queueLabel string) (text string, ok bool, err error) {
+	snap, err := ctx.Bot.store.LatestSnapshot(player.ID, queue)
+	if err != nil || snap == nil {
+		return "", false, err
+	}
+	loc, err := guildLocale(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	return fmt.Sprintf("**%s#%s** (%s): %s\n⚠ Riot APIに接続できないため、%sの記録を表示しています。",
+		player.GameName, player.TagLine, queueLabel,
+		rank.Format(snap.Tier, snap.Division, snap.LP),
+		loc.AsOf(snap.TakenAt.In(render.JST))), true, nil
+}
+
+func cmdDaystats(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "daystats <ゲーム名#タグ>|all [YYYYMMDD]`")
+	}
+	if strings.ToLower(ctx.Args[0]) == "all" {
+		day := time.Now().In(render.JST)
+		if len(ctx.Args) >= 2 {
+			parsed, err := time.ParseInLocation("20060102", ctx.Args[1], render.JST)
+			if err != nil {
+				return ctx.Reply("日付は `YYYYMMDD` の形式で指定してください。")
+			}
+			day = parsed
+		}
+		from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, render.JST)
+		text, err := daystatsAllText(ctx, from)
+		if err != nil {
+			return err
+		}
+		return ctx.Reply(text)
+	}
+	gameName, tagLine, ok := parseRiotID(ctx.Args[0])
+	if !ok {
+		return ctx.Reply("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	day := time.Now().In(render.JST)
+	picked := len(ctx.Args) >= 2
+	if picked {
+		parsed, err := time.ParseInLocation("20060102", ctx.Args[1], render.JST)
+		if err != nil {
+			return ctx.Reply("日付は `YYYYMMDD` の形式で指定してください。")
+		}
+		day = parsed
+	}
+
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if err == storage.ErrNotRegistered {
+		return ctx.Reply("そのプレイヤーは登録されていません。`" + ctx.Bot.cfg.Prefix + "add` で登録してください。")
+	}
+	if err != nil {
+		return err
+	}
+
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, render.JST)
+	text, err := daystatsText(ctx, player, from)
+	if err != nil {
+		return err
+	}
+	if picked {
+		return ctx.Reply(text)
+	}
+	// No explicit date: attach a picker of the last two weeks so other
+	// days are one selection away instead of a typed YYYYMMDD.
+	return sendDaystatsPicker(ctx, player, text)
+}
+
+// daystatsText renders one player's record for the JST day starting at
+// from, with the standard freshness footer.
+func daystatsText(ctx *Context, player *storage.Player, from time.Time) (string, error) {
+	matches, err := ctx.Bot.store.MatchesForPlayer(player.ID, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		return "", err
+	}
+	wins, losses := 0, 0
+	kills, deaths, assists := 0, 0, 0
+	var lines []string
+	// Matches come newest first; walk them backwards so the day reads
+	// chronologically.
+	for i := len(matches) - 1; i >= 0; i-- {
+		m := matches[i]
+		for _, p := range m.Info.Participants {
+			if p.PUUID != player.PUUID {
+				continue
+			}
+			result := "×"
+			if p.Win {
+				result = "○"
+				wins++
+			} else {
+				losses++
+			}
+			kills += p.Kills
+			deaths += p.Deaths
+			assists += p.Assists
+			lines = append(lines, fmt.Sprintf("%s %-14s %2d/%2d/%2d CS%-3d %d分",
+				result, p.ChampionName, p.Kills, p.Deaths, p.Assists, p.CS(), m.Info.GameDuration/60))
+			break
+		}
+	}
+	loc, err := guildLocale(ctx)
+	if err != nil {
+		return "", err
+	}
+	text := fmt.Sprintf("**%s** の %s の戦績: 対戦なし", player.RiotID(), loc.Date(from))
+	if wins+losses > 0 {
+		kda := float64(kills+assists) / float64(max(deaths, 1))
+		text = fmt.Sprintf("**%s** の %s の戦績: %s ／ KDA %.2f (%d/%d/%d)\n```\n%s\n```",
+			player.RiotID(), loc.Date(from), loc.WinLoss(wins, losses),
+			kda, kills, deaths, assists, strings.Join(lines, "\n"))
+	}
+	if footer := playerFreshness(ctx, player); footer != "" {
+		text += "\n" + footer
+	}
+	return text, nil
+}
+
+// playerFreshness builds the standard staleness footer from one
+// player's cache metadata; empty when nothing has been fetched yet.
+func playerFreshness(ctx *Context, player *storage.Player) string {
+	var leagueAt, matchAt time.Time
+	if snap, err := ctx.Bot.store.LatestSnapshot(player.ID, riotapi.QueueSolo); err == nil && snap != nil {
+		leagueAt = snap.TakenAt
+	}
+	if at, err := ctx.Bot.store.LastMatchFetch(player.ID); err == nil {
+		matchAt = at
+	}
+	return render.FreshnessFooter(leagueAt, matchAt)
+}
+
+func cmdAdd(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "add <ゲーム名#タグ>`")
+	}
+	raw := strings.Join(ctx.Args, " ")
+	// An op.gg multi-search link or a comma-separated blob registers the
+	// whole list at once.
+	if ids, ok := opggMultiIDs(raw); ok {
+		return addMany(ctx, ids)
+	}
+	if ids := splitImportEntries(raw); len(ids) > 1 {
+		return addMany(ctx, ids)
+	}
+	gameName, tagLine, ok := parseRiotID(raw)
+	if !ok {
+		return ctx.Reply("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	player, added, err := registerRiotID(ctx, gameName, tagLine)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return ctx.Reply(fmt.Sprintf("**%s** はすでに登録されています。", player.RiotID()))
+	}
+	return ctx.Reply(fmt.Sprintf("**%s** を登録しました。ランク情報はまもなく反映されます。", player.RiotID()))
+}
+
+// registerRiotID resolves a Riot ID against the Account API and puts it
+// on the roster, queueing a background refresh for new entries.
+func registerRiotID(ctx *Context, gameName, tagLine string) (*storage.Player, bool, error) {
+	account, err := ctx.Bot.riot.AccountByRiotID(gameName, tagLine)
+	if err != nil {
+		return nil, false, err
+	}
+	platform, err := detectPlatform(ctx, account.PUUID)
+	if err != nil {
+		return nil, false, err
+	}
+	summoner, err := ctx.Bot.riot.ForPlatform(platform).SummonerByPUUID(account.PUUID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := ctx.Bot.store.EnsureGuild(ctx.GuildID()); err != nil {
+		return nil, false, err
+	}
+	player := &storage.Player{
+		GuildID:    ctx.GuildID(),
+		GameName:   account.GameName,
+		TagLine:    account.TagLine,
+		PUUID:      account.PUUID,
+		SummonerID: summoner.ID,
+		Platform:   platform,
+	}
+	added, err := ctx.Bot.store.AddPlayer(player)
+	if err != nil || !added {
+		return player, false, err
+	}
+	// Let the worker pull league data and recent matches in the background.
+	if err := ctx.Bot.store.EnqueueTask(storage.TaskRefreshPlayer, strconv.FormatInt(player.ID, 10)); err != nil {
+		return nil, false, err
+	}
+	return player, true, nil
+}
+
+func cmdSchedule(ctx *Context) error {
+	if len(ctx.Args) < 1 || ctx.Args[0] != "daily" {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "schedule daily [cron式]`")
+	}
+	if len(ctx.Args) == 1 {
+		spec, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingDailyPostCron, "0 5 * * *")
+		if err != nil {
+			return err
+		}
+		return ctx.Reply(fmt.Sprintf("日次ランキング投稿のスケジュール: `%s` (JST)", spec))
+	}
+	spec := strings.Join(ctx.Args[1:], " ")
+	if err := sched.Validate(spec); err != nil {
+		return ctx.Reply(fmt.Sprintf("cron式 `%s` を解釈できません。例: `0 5 * * *` (毎日5時)", spec))
+	}
+	if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingDailyPostCron, spec); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("日次ランキング投稿を `%s` (JST) に設定しました。", spec))
+}
+
+func cmdStorage(ctx *Context) error {
+	st, err := ctx.Bot.store.DBStats()
+	if err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf(
+		"**ストレージ使用状況**\n```\nDBサイズ      : %.1f MB\nプレイヤー    : %d\nスナップショット: %d\n試合キャッシュ : %d\nタスク        : %d\n```",
+		float64(st.SizeBytes)/(1024*1024), st.Players, st.Snapshots, st.Matches, st.Tasks))
+}
+
+// announceKinds maps !setchannel arguments to their setting keys.
+var announceKinds = map[string]struct {
+	key   string
+	label string
+}{
+	"daily":      {storage.SettingChannelDaily, "日次ランキング"},
+	"recap":      {storage.SettingChannelRecap, "デイリーまとめ"},
+	"promotions": {storage.SettingChannelPromotions, "昇格通知"},
+	"live":       {storage.SettingChannelLive, "観戦速報"},
+}
+
+func cmdSetChannel(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		if err := ctx.Bot.store.SetAnnounceChannel(ctx.GuildID(), ctx.Message.ChannelID); err != nil {
+			return err
+		}
+		return ctx.Reply("このチャンネルを既定の投稿先に設定しました。")
+	}
+	kind, ok := announceKinds[strings.ToLower(ctx.Args[0])]
+	if !ok {
+		return ctx.Reply("種類は daily / recap / promotions / live のいずれかで指定してください。")
+	}
+	if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), kind.key, ctx.Message.ChannelID); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("%sの投稿先をこのチャンネルに設定しました。", kind.label))
+}
+
+// detectPlatform asks the account-v1 region endpoint where a PUUID
+// actively plays, falling back to the configured platform when the
+// endpoint has no answer.
+func detectPlatform(ctx *Context, puuid string) (string, error) {
+	platform, err := ctx.Bot.riot.ActivePlatform(puuid)
+	switch {
+	case err == nil && riotapi.ValidPlatform(platform):
+		return platform, nil
+	case err == nil, errors.Is(err, riotapi.ErrNotFound), errors.Is(err, riotapi.ErrForbidden):
+		return ctx.Bot.cfg.Platform, nil
+	default:
+		return "", err
+	}
+}
+
+// parseRiotID splits "gameName#tagLine"; gameName may contain spaces.
+func parseRiotID(s string) (gameName, tagLine string, ok bool) {
+	i := strings.LastIndex(s, "#")
+	if i <= 0 || i == len(s)-1 {
+		return "", "", false
+	}
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:]), true
+}
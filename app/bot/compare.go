@@ -0,0 +1,176 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// compareWindowDays is the window the guild-average comparison samples;
+// compareMinGames is the minimum before a player counts toward it.
+const (
+	compareWindowDays = 14
+	compareMinGames   = 3
+)
+
+// periodStats aggregates one player's performance over a window of
+// cached solo-queue games.
+type periodStats struct {
+	games, wins            int
+	kills, deaths, assists int
+	cs                     int
+	visionScore            int
+	seconds                int64
+	// surrenderedLosses counts losses ended by a surrender vote, as
+	// opposed to played-out defeats.
+	surrenderedLosses int
+}
+
+func statsForPlayer(store *storage.Store, p *storage.Player, from, to time.Time) (*periodStats, error) {
+	matches, err := store.MatchesForPlayer(p.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var st periodStats
+	for _, m := range matches {
+		if m.Info.QueueID != riotapi.QueueIDSolo {
+			continue
+		}
+		for _, part := range m.Info.Participants {
+			if part.PUUID != p.PUUID {
+				continue
+			}
+			st.games++
+			if part.Win {
+				st.wins++
+			} else if part.GameEndedInSurrender || part.GameEndedInEarlySurrender {
+				st.surrenderedLosses++
+			}
+			st.kills += part.Kills
+			st.deaths += part.Deaths
+			st.assists += part.Assists
+			st.cs += part.CS()
+			st.visionScore += part.VisionScore
+			st.seconds += m.Info.GameDuration
+			break
+		}
+	}
+	return &st, nil
+}
+
+func (st *periodStats) kda() float64 {
+	deaths := st.deaths
+	if deaths == 0 {
+		deaths = 1
+	}
+	return float64(st.kills+st.assists) / float64(deaths)
+}
+
+func (st *periodStats) csPerMin() float64 {
+	if st.seconds == 0 {
+		return 0
+	}
+	return float64(st.cs) / (float64(st.seconds) / 60)
+}
+
+func (st *periodStats) visionPerGame() float64 {
+	if st.games == 0 {
+		return 0
+	}
+	return float64(st.visionScore) / float64(st.games)
+}
+
+func (st *periodStats) winrate() float64 {
+	if st.games == 0 {
+		return 0
+	}
+	return float64(st.wins) / float64(st.games) * 100
+}
+
+// neverFFLine renders how often the player's recent losses were played
+// out instead of surrendered; empty without any losses to judge.
+func neverFFLine(st *periodStats) string {
+	losses := st.games - st.wins
+	if losses == 0 {
+		return ""
+	}
+	playedOut := losses - st.surrenderedLosses
+	rate := float64(playedOut) / float64(losses) * 100
+	return fmt.Sprintf("🏳 FFしない率: %.0f%% (直近%d日の敗北%d試合中%d試合を完走)",
+		rate, compareWindowDays, losses, playedOut)
+}
+
+// groupComparison renders how a player's recent KDA, CS/min, vision and
+// winrate sit against every registered player in the guild. Empty when
+// there isn't enough recent data to compare.
+func groupComparison(ctx *Context, target *storage.Player) (string, error) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -compareWindowDays)
+
+	mine, err := statsForPlayer(ctx.Bot.store, target, from, to)
+	if err != nil {
+		return "", err
+	}
+	if mine.games < compareMinGames {
+		return "", nil
+	}
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return "", err
+	}
+	group := make([]*periodStats, 0, len(players))
+	for i := range players {
+		st, err := statsForPlayer(ctx.Bot.store, &players[i], from, to)
+		if err != nil {
+			return "", err
+		}
+		if st.games >= compareMinGames {
+			group = append(group, st)
+		}
+	}
+	if len(group) < 2 {
+		return "", nil
+	}
+
+	metrics := []struct {
+		label string
+		unit  string
+		value func(*periodStats) float64
+	}{
+		{"KDA", "", (*periodStats).kda},
+		{"CS/分", "", (*periodStats).csPerMin},
+		{"視界", "", (*periodStats).visionPerGame},
+		{"勝率", "%", (*periodStats).winrate},
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**ギルド平均との比較** (直近%d日, %d試合)\n```\n", compareWindowDays, mine.games)
+	for _, metric := range metrics {
+		own := metric.value(mine)
+		var sum float64
+		below := 0
+		for _, st := range group {
+			v := metric.value(st)
+			sum += v
+			if v <= own {
+				below++
+			}
+		}
+		pct := float64(below) / float64(len(group)) * 100
+		fmt.Fprintf(&b, "%-5s %5.1f%s (平均 %.1f%s) %s\n",
+			metric.label, own, metric.unit, sum/float64(len(group)), metric.unit, percentileBar(pct))
+	}
+	b.WriteString("```")
+	return b.String(), nil
+}
+
+// percentileBar renders a ten-cell bar filled to the group percentile.
+func percentileBar(pct float64) string {
+	filled := int(pct/10 + 0.5)
+	if filled > 10 {
+		filled = 10
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// compareSeasonsDays caps how many of the current season's observed
+// days the table lists; the pace verdict always uses the newest one.
+const compareSeasonsDays = 14
+
+// cmdCompareSeasons charts this season's LP curve against the same days
+// of the previous season. Seasons follow the bot's goal scoping — one
+// calendar year each — and days are aligned by offset from January 1st,
+// with the previous season's value carried forward over gaps so a
+// missed day still has a pace to compare against.
+func cmdCompareSeasons(ctx *Context) error {
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return errUsage("使い方: `" + ctx.Bot.cfg.Prefix + "compareseasons <ゲーム名#タグ>`")
+	}
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if errors.Is(err, storage.ErrNotRegistered) {
+		return ctx.Reply(fmt.Sprintf("**%s#%s** は登録されていません。", gameName, tagLine))
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().In(render.JST)
+	currStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, render.JST)
+	prevStart := currStart.AddDate(-1, 0, 0)
+	snaps, err := ctx.Bot.store.DailySnapshots(player.ID, riotapi.QueueSolo, prevStart)
+	if err != nil {
+		return err
+	}
+	var curr, prev []storage.Snapshot
+	for _, snap := range snaps {
+		if snap.TakenAt.Before(currStart) {
+			prev = append(prev, snap)
+		} else {
+			curr = append(curr, snap)
+		}
+	}
+	if len(curr) == 0 {
+		return ctx.Reply(fmt.Sprintf("**%s** の今シーズンのスナップショットはまだありません。", player.RiotID()))
+	}
+	if len(prev) == 0 {
+		return ctx.Reply(fmt.Sprintf("**%s** の前シーズンのスナップショットが残っていないため、比較できません。", player.RiotID()))
+	}
+
+	// prevAt returns the previous season's standing as of the given
+	// day-of-season offset, carrying the last observation forward.
+	prevAt := func(offset int) *storage.Snapshot {
+		var last *storage.Snapshot
+		for i := range prev {
+			if seasonDay(prev[i].TakenAt, prevStart) > offset {
+				break
+			}
+			last = &prev[i]
+		}
+		return last
+	}
+
+	if len(curr) > compareSeasonsDays {
+		curr = curr[len(curr)-compareSeasonsDays:]
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s のシーズン比較** (%d vs %d)\n```\n", player.RiotID(), now.Year(), now.Year()-1)
+	for _, snap := range curr {
+		line := fmt.Sprintf("%s  %-18s", snap.TakenAt.In(render.JST).Format("01/02"),
+			rank.Format(snap.Tier, snap.Division, snap.LP))
+		if p := prevAt(seasonDay(snap.TakenAt, currStart)); p != nil {
+			diff := rank.Value(snap.Tier, snap.Division, snap.LP) - rank.Value(p.Tier, p.Division, p.LP)
+			line += fmt.Sprintf(" | 昨季 %-18s %+dLP相当", rank.Format(p.Tier, p.Division, p.LP), diff)
+		} else {
+			line += " | 昨季 記録なし"
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("```")
+
+	latest := curr[len(curr)-1]
+	if p := prevAt(seasonDay(latest.TakenAt, currStart)); p != nil {
+		diff := rank.Value(latest.Tier, latest.Division, latest.LP) - rank.Value(p.Tier, p.Division, p.LP)
+		switch {
+		case diff > 0:
+			fmt.Fprintf(&b, "\n📈 昨シーズンの同時期より **%+dLP相当** 先行しています。", diff)
+		case diff < 0:
+			fmt.Fprintf(&b, "\n📉 昨シーズンの同時期より **%dLP相当** 遅れています。", -diff)
+		default:
+			b.WriteString("\n→ 昨シーズンの同時期とちょうど同じペースです。")
+		}
+	}
+	return ctx.Reply(b.String())
+}
+
+// seasonDay is the snapshot's day offset from its season's start.
+func seasonDay(t, seasonStart time.Time) int {
+	return int(t.In(render.JST).Sub(seasonStart).Hours() / 24)
+}
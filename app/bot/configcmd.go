@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/feature"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// guildConfig is the exchange format of !config export/import. It
+// carries resolved PUUIDs so importing never needs the Riot API.
+type guildConfig struct {
+	AnnounceChannelID string            `json:"announce_channel_id,omitempty"`
+	Settings          map[string]string `json:"settings,omitempty"`
+	Roster            []rosterEntry     `json:"roster,omitempty"`
+}
+
+type rosterEntry struct {
+	GameName   string `json:"game_name"`
+	TagLine    string `json:"tag_line"`
+	PUUID      string `json:"puuid"`
+	SummonerID string `json:"summoner_id"`
+	Platform   string `json:"platform"`
+}
+
+// settableKeys are the settings !config set may touch directly; the
+// schedule/format keys have their own commands with validation.
+var settableKeys = map[string]bool{
+	storage.SettingRecapVision: true,
+	storage.SettingIntCheck:    true,
+	storage.SettingLocale:      true,
+}
+
+func cmdConfig(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "config export` / `" + ctx.Bot.cfg.Prefix + "config import <JSON>` / `" +
+			ctx.Bot.cfg.Prefix + "config set <キー> <値>`")
+	}
+	switch ctx.Args[0] {
+	case "export":
+		return configExport(ctx)
+	case "import":
+		return configImport(ctx, strings.Join(ctx.Args[1:], " "))
+	case "set":
+		return configSet(ctx, ctx.Args[1:])
+	default:
+		return ctx.Reply("`export` / `import` / `set` を指定してください。")
+	}
+}
+
+func configSet(ctx *Context, args []string) error {
+	if len(args) != 2 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "config set <キー> <値>` 例: `" +
+			ctx.Bot.cfg.Prefix + "config set recap.vision off`")
+	}
+	key, value := args[0], args[1]
+	if name, isFlag := strings.CutPrefix(key, "feature."); isFlag {
+		if !feature.Known(name) || (value != "on" && value != "off") {
+			return ctx.Reply("`feature.<名前>` には既知のフラグを `on` / `off` で指定してください。")
+		}
+	} else if !settableKeys[key] {
+		keys := make([]string, 0, len(settableKeys))
+		for k := range settableKeys {
+			keys = append(keys, "`"+k+"`")
+		}
+		sort.Strings(keys)
+		return ctx.Reply("設定できるキー: " + strings.Join(keys, " "))
+	}
+	if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), key, value); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("`%s` を `%s` に設定しました。", key, value))
+}
+
+func configExport(ctx *Context) error {
+	cfg := guildConfig{}
+	var err error
+	if cfg.AnnounceChannelID, err = ctx.Bot.store.AnnounceChannel(ctx.GuildID()); err != nil {
+		return err
+	}
+	if cfg.Settings, err = ctx.Bot.store.AllGuildSettings(ctx.GuildID()); err != nil {
+		return err
+	}
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	for _, p := range players {
+		cfg.Roster = append(cfg.Roster, rosterEntry{
+			GameName: p.GameName, TagLine: p.TagLine,
+			PUUID: p.PUUID, SummonerID: p.SummonerID, Platform: p.Platform,
+		})
+	}
+	blob, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if len(blob) > 1900 {
+		_, err = ctx.Session.ChannelFileSend(ctx.Message.ChannelID,
+			"guild-config.json", strings.NewReader(string(blob)))
+		return err
+	}
+	return ctx.Reply("```json\n" + string(blob) + "\n```")
+}
+
+func configImport(ctx *Context, raw string) error {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.Trim(raw, "`\n ")
+	if raw == "" {
+		return ctx.Reply("`" + ctx.Bot.cfg.Prefix + "config import` の後にJSONを貼り付けてください。")
+	}
+	var cfg guildConfig
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return ctx.Reply("JSONを解釈できませんでした: " + err.Error())
+	}
+
+	if cfg.AnnounceChannelID != "" {
+		if err := ctx.Bot.store.SetAnnounceChannel(ctx.GuildID(), cfg.AnnounceChannelID); err != nil {
+			return err
+		}
+	}
+	for k, v := range cfg.Settings {
+		if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), k, v); err != nil {
+			return err
+		}
+	}
+	added := 0
+	for _, e := range cfg.Roster {
+		if e.PUUID == "" {
+			continue
+		}
+		ok, err := ctx.Bot.store.AddPlayer(&storage.Player{
+			GuildID:  ctx.GuildID(),
+			GameName: e.GameName, TagLine: e.TagLine,
+			PUUID: e.PUUID, SummonerID: e.SummonerID, Platform: e.Platform,
+		})
+		if err != nil {
+			return err
+		}
+		if ok {
+			added++
+		}
+	}
+	return ctx.Reply(fmt.Sprintf("設定を取り込みました（設定 %d件、プレイヤー %d人を追加）。",
+		len(cfg.Settings), added))
+}
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// consistencyWindowDays is how far back daily LP changes are sampled.
+const consistencyWindowDays = 30
+
+// playerVolatility computes the LP volatility of a player's last 30
+// days from daily snapshots; ok is false without enough history.
+func playerVolatility(store *storage.Store, playerID int64) (sigma float64, ok bool, err error) {
+	from := time.Now().AddDate(0, 0, -consistencyWindowDays)
+	snaps, err := store.DailySnapshots(playerID, riotapi.QueueSolo, from)
+	if err != nil {
+		return 0, false, err
+	}
+	values := make([]int, 0, len(snaps))
+	for _, s := range snaps {
+		values = append(values, rank.Value(s.Tier, s.Division, s.LP))
+	}
+	sigma, ok = rank.Volatility(values)
+	return sigma, ok, nil
+}
+
+// consistencyRanking lists players ordered steadiest-first by LP
+// volatility.
+func consistencyRanking(ctx *Context, players []storage.RankedPlayer) error {
+	type row struct {
+		player storage.RankedPlayer
+		sigma  float64
+	}
+	var rows []row
+	for _, p := range players {
+		sigma, ok, err := playerVolatility(ctx.Bot.store, p.ID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			rows = append(rows, row{player: p, sigma: sigma})
+		}
+	}
+	if len(rows) == 0 {
+		return ctx.Reply("安定度を計算できるだけのスナップショット履歴がまだありません。")
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].sigma < rows[j].sigma })
+
+	var b strings.Builder
+	b.WriteString("**安定度ランキング** (直近30日のLP変動の少なさ)\n```\n")
+	for i, r := range rows {
+		fmt.Fprintf(&b, "%2d. %-24s σ=%.0fLP/日 (%s)\n",
+			i+1, r.player.RiotID(), r.sigma,
+			rank.Format(r.player.Tier, r.player.Division, r.player.LP))
+	}
+	b.WriteString("```")
+	return ctx.Reply(b.String())
+}
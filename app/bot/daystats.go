@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// daystatsPickerDays is how far back the date picker reaches.
+const daystatsPickerDays = 14
+
+// jaWeekdays maps time.Weekday to the Japanese single-character form.
+var jaWeekdays = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// sendDaystatsPicker posts today's record with a select menu of the
+// last two weeks, each day labeled with its weekday and how many games
+// the cache holds for it. The target player is persisted per message so
+// the menu survives gateway restarts.
+func sendDaystatsPicker(ctx *Context, player *storage.Player, text string) error {
+	today := time.Now().In(render.JST)
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, render.JST)
+	perDay, err := playerGamesByDay(ctx.Bot.store, player, start.AddDate(0, 0, -(daystatsPickerDays-1)), start.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	options := make([]discordgo.SelectMenuOption, 0, daystatsPickerDays)
+	for i := 0; i < daystatsPickerDays; i++ {
+		day := start.AddDate(0, 0, -i)
+		key := day.Format("2006-01-02")
+		options = append(options, discordgo.SelectMenuOption{
+			Label: fmt.Sprintf("%s (%s) %d試合", day.Format("01/02"), jaWeekdays[day.Weekday()], perDay[key]),
+			Value: day.Format("20060102"),
+		})
+	}
+	sent, err := ctx.Session.ChannelMessageSendComplex(ctx.Message.ChannelID, &discordgo.MessageSend{
+		Content: text,
+		Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    "daystats:pick",
+				Placeholder: "別の日を見る",
+				Options:     options,
+			},
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Bot.store.SaveInteractionState(sent.ID, ctx.GuildID(), "daystats", player.RiotID())
+}
+
+// daystatsAllText aggregates one day's cached games for every
+// registered player into a table, most games first, so the day's
+// biggest grinders top the list.
+func daystatsAllText(ctx *Context, from time.Time) (string, error) {
+	recap, err := ctx.Bot.store.RecapData(ctx.GuildID(), from, from.AddDate(0, 0, 1))
+	if err != nil {
+		return "", err
+	}
+	type row struct {
+		name        string
+		wins, games int
+	}
+	var rows []row
+	for _, pm := range recap {
+		if len(pm.Matches) == 0 {
+			continue
+		}
+		wins := 0
+		for _, m := range pm.Matches {
+			for _, part := range m.Info.Participants {
+				if part.PUUID == pm.PUUID && part.Win {
+					wins++
+				}
+			}
+		}
+		rows = append(rows, row{pm.RiotID(), wins, len(pm.Matches)})
+	}
+	if len(rows) == 0 {
+		return fmt.Sprintf("%s (%s) はどのプレイヤーにも試合データがありません。",
+			from.Format("01/02"), jaWeekdays[from.Weekday()]), nil
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].games != rows[j].games {
+			return rows[i].games > rows[j].games
+		}
+		return rows[i].wins > rows[j].wins
+	})
+	var b strings.Builder
+	fmt.Fprintf(&b, "**全員の戦績** %s (%s)\n```\n", from.Format("01/02"), jaWeekdays[from.Weekday()])
+	for i, r := range rows {
+		fmt.Fprintf(&b, "%2d. %-24s %d勝%d敗 (%d試合)\n", i+1, r.name, r.wins, r.games-r.wins, r.games)
+	}
+	b.WriteString("```")
+	return b.String(), nil
+}
+
+// playerGamesByDay counts one player's cached games per JST day in
+// [from, to).
+func playerGamesByDay(store *storage.Store, player *storage.Player, from, to time.Time) (map[string]int, error) {
+	matches, err := store.MatchesForPlayer(player.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	perDay := map[string]int{}
+	for _, m := range matches {
+		end := time.UnixMilli(m.Info.GameEndTimestamp).In(render.JST)
+		perDay[end.Format("2006-01-02")]++
+	}
+	return perDay, nil
+}
+
+// handleDaystatsComponent answers a date picked from the menu with that
+// day's record, ephemerally so the original message stays put.
+func (b *Bot) handleDaystatsComponent(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.MessageComponentInteractionData) error {
+	reply := func(content string) error {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: content,
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+	state, err := b.store.InteractionState(i.Message.ID)
+	if err != nil {
+		return err
+	}
+	if state == nil || state.Kind != "daystats" || len(data.Values) == 0 {
+		return reply("この日付選択は期限切れです。もう一度コマンドを実行してください。")
+	}
+	gameName, tagLine, ok := parseRiotID(state.Payload)
+	if !ok {
+		return nil
+	}
+	player, err := b.store.PlayerByRiotID(i.GuildID, gameName, tagLine)
+	if err != nil {
+		return reply(userMessage(err))
+	}
+	day, err := time.ParseInLocation("20060102", data.Values[0], render.JST)
+	if err != nil {
+		return err
+	}
+	ctx := &Context{
+		Bot:     b,
+		Session: s,
+		Message: &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: i.GuildID, ChannelID: i.ChannelID}},
+	}
+	text, err := daystatsText(ctx, player, day)
+	if err != nil {
+		return err
+	}
+	return reply(text)
+}
@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// duoTierRanges is Riot's duo-queue restriction table: for each tier,
+// the lowest and highest tier it may queue with. Master and above
+// cannot duo at all; within Diamond the gap is further limited to two
+// divisions, which we approximate below.
+var duoTierRanges = map[string][2]string{
+	"IRON":     {"IRON", "SILVER"},
+	"BRONZE":   {"IRON", "SILVER"},
+	"SILVER":   {"IRON", "GOLD"},
+	"GOLD":     {"SILVER", "PLATINUM"},
+	"PLATINUM": {"GOLD", "EMERALD"},
+	"EMERALD":  {"PLATINUM", "DIAMOND"},
+	"DIAMOND":  {"EMERALD", "DIAMOND"},
+}
+
+// diamondDivisionGap is the maximum division distance between two
+// Diamond players who want to duo.
+const diamondDivisionGap = 2
+
+func cmdCanPlayWith(ctx *Context) error {
+	ids := splitRiotIDs(ctx.Args)
+	if len(ids) != 2 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "canplaywith <ゲーム名#タグ> <ゲーム名#タグ>`")
+	}
+	type side struct {
+		name, tier, division string
+	}
+	var sides [2]side
+	for i, id := range ids {
+		gameName, tagLine, ok := parseRiotID(id)
+		if !ok {
+			return ctx.Reply(fmt.Sprintf("`%s` はRiot IDとして解釈できません。`ゲーム名#タグ` の形式で指定してください。", id))
+		}
+		name, entry, err := soloEntryFor(ctx, gameName, tagLine)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return ctx.Reply(fmt.Sprintf("**%s** は今シーズンのソロランクがないため判定できません。", name))
+		}
+		sides[i] = side{name: name, tier: entry.Tier, division: entry.Rank}
+	}
+
+	ok, reason := canDuo(sides[0].tier, sides[0].division, sides[1].tier, sides[1].division)
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** (%s) × **%s** (%s)\n",
+		sides[0].name, strings.TrimSpace(sides[0].tier+" "+sides[0].division),
+		sides[1].name, strings.TrimSpace(sides[1].tier+" "+sides[1].division))
+	if ok {
+		b.WriteString("✅ デュオ可能です！\n")
+	} else {
+		b.WriteString("❌ デュオできません。" + reason + "\n")
+	}
+	for _, s := range sides {
+		if r, known := duoTierRanges[strings.ToUpper(s.tier)]; known {
+			fmt.Fprintf(&b, "%s の許可範囲: %s〜%s\n", s.name, r[0], r[1])
+		}
+	}
+	return ctx.Reply(strings.TrimRight(b.String(), "\n"))
+}
+
+// canDuo applies the restriction table in both directions.
+func canDuo(tier1, div1, tier2, div2 string) (bool, string) {
+	t1, t2 := strings.ToUpper(tier1), strings.ToUpper(tier2)
+	r1, ok1 := duoTierRanges[t1]
+	r2, ok2 := duoTierRanges[t2]
+	if !ok1 || !ok2 {
+		return false, "マスター以上はデュオでランク戦に参加できません。"
+	}
+	if !tierInRange(t2, r1) || !tierInRange(t1, r2) {
+		return false, "ティアが離れすぎています。"
+	}
+	if t1 == "DIAMOND" && t2 == "DIAMOND" {
+		gap := rank.Value(t1, div1, 0) - rank.Value(t2, div2, 0)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap/100 > diamondDivisionGap {
+			return false, "ダイヤモンド内ではディビジョン差2以内が必要です。"
+		}
+	}
+	return true, ""
+}
+
+func tierInRange(tier string, r [2]string) bool {
+	v := rank.Value(tier, "IV", 0)
+	return v >= rank.Value(r[0], "IV", 0) && v <= rank.Value(r[1], "IV", 0)
+}
+
+// soloEntryFor resolves a Riot ID (registered players skip the account
+// lookup) and returns its solo-queue league entry, nil if unranked.
+func soloEntryFor(ctx *Context, gameName, tagLine string) (string, *riotapi.LeagueEntryDTO, error) {
+	var summonerID string
+	riot := ctx.Bot.riot
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	switch {
+	case err == nil:
+		summonerID = player.SummonerID
+		gameName, tagLine = player.GameName, player.TagLine
+		riot = riot.ForPlatform(player.Platform)
+	case err == storage.ErrNotRegistered:
+		account, err := ctx.Bot.riot.AccountByRiotID(gameName, tagLine)
+		if err != nil {
+			return "", nil, err
+		}
+		platform, err := detectPlatform(ctx, account.PUUID)
+		if err != nil {
+			return "", nil, err
+		}
+		riot = riot.ForPlatform(platform)
+		summoner, err := riot.SummonerByPUUID(account.PUUID)
+		if err != nil {
+			return "", nil, err
+		}
+		summonerID = summoner.ID
+		gameName, tagLine = account.GameName, account.TagLine
+	default:
+		return "", nil, err
+	}
+	name := gameName + "#" + tagLine
+	entries, err := riot.LeagueEntriesBySummonerID(summonerID)
+	if err != nil {
+		return name, nil, err
+	}
+	for i := range entries {
+		if entries[i].QueueType == riotapi.QueueSolo {
+			return name, &entries[i], nil
+		}
+	}
+	return name, nil, nil
+}
+
+// splitRiotIDs groups args into Riot IDs: game names may contain
+// spaces, so each "#tag" token closes the current ID.
+func splitRiotIDs(args []string) []string {
+	var ids []string
+	var current []string
+	for _, arg := range args {
+		current = append(current, arg)
+		if strings.Contains(arg, "#") {
+			ids = append(ids, strings.Join(current, " "))
+			current = nil
+		}
+	}
+	return ids
+}
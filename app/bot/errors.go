@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"errors"
+
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// usageError carries a message that should reach the user verbatim,
+// for misuse detected deep inside a handler.
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+func errUsage(msg string) error { return usageError{msg: msg} }
+
+// userMessage translates a handler error into the reply users see.
+// Typed riot client errors become actionable Japanese messages; raw
+// status codes and response bodies stay in the logs only.
+func userMessage(err error) string {
+	var usage usageError
+	if errors.As(err, &usage) {
+		return usage.msg
+	}
+	switch {
+	case errors.Is(err, riotapi.ErrNotFound):
+		return "そのRiot IDは存在しません。ゲーム名とタグ（`#` の後ろ）に間違いがないか確認してください。"
+	case errors.Is(err, riotapi.ErrRateLimited):
+		return "Riot APIが混雑しています。1〜2分待ってからもう一度お試しください。"
+	case errors.Is(err, riotapi.ErrForbidden):
+		return "Riot APIキーが無効になっています。Botの管理者に連絡してください。"
+	case errors.Is(err, riotapi.ErrUnavailable):
+		return "Riot APIが一時的に利用できないようです。時間をおいて再度お試しください。"
+	default:
+		return "エラーが発生しました。時間をおいて再度お試しください。"
+	}
+}
@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// flexNightQuorum is how many confirmations lock a session in;
+// flexNightDefaultTime applies when only a date (or nothing) is given.
+const (
+	flexNightQuorum      = 5
+	flexNightDefaultTime = "21:00"
+)
+
+// flexState is a flex-night poll's state, persisted per message so the
+// poll survives gateway restarts.
+type flexState struct {
+	At        int64    `json:"at"` // unix seconds of the planned session
+	ChannelID string   `json:"channel_id"`
+	Users     []string `json:"users"`
+	Scheduled bool     `json:"scheduled"`
+}
+
+// cmdFlexNight posts an availability poll for a play session. Once the
+// quorum confirms, the worker pings the participants at the planned
+// time.
+func cmdFlexNight(ctx *Context) error {
+	dateArg := time.Now().In(render.JST).Format("20060102")
+	timeArg := flexNightDefaultTime
+	if len(ctx.Args) >= 1 {
+		dateArg = ctx.Args[0]
+	}
+	if len(ctx.Args) >= 2 {
+		timeArg = ctx.Args[1]
+	}
+	at, err := time.ParseInLocation("20060102 15:04", dateArg+" "+timeArg, render.JST)
+	if err != nil {
+		return errUsage("使い方: `" + ctx.Bot.cfg.Prefix + "flexnight [YYYYMMDD] [HH:MM]`（省略時は今日の" + flexNightDefaultTime + "）")
+	}
+	if !at.After(time.Now()) {
+		return ctx.Reply("過去の日時では募集できません。")
+	}
+	state := flexState{At: at.Unix(), ChannelID: ctx.Message.ChannelID}
+	sent, err := ctx.Session.ChannelMessageSendComplex(ctx.Message.ChannelID, &discordgo.MessageSend{
+		Content:    flexNightText(at, &state),
+		Components: flexNightButtons(),
+	})
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(&state)
+	if err != nil {
+		return err
+	}
+	return ctx.Bot.store.SaveInteractionState(sent.ID, ctx.GuildID(), "flexnight", string(payload))
+}
+
+// flexNightText renders the poll message for its current state.
+func flexNightText(at time.Time, s *flexState) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**フレックスナイト募集** — %s (%s) %s\n",
+		at.Format("01/02"), jaWeekdays[at.Weekday()], at.Format("15:04"))
+	fmt.Fprintf(&b, "参加表明 %d/%d人", len(s.Users), flexNightQuorum)
+	if len(s.Users) > 0 {
+		mentions := make([]string, 0, len(s.Users))
+		for _, id := range s.Users {
+			mentions = append(mentions, "<@"+id+">")
+		}
+		b.WriteString(": " + strings.Join(mentions, " "))
+	}
+	if s.Scheduled {
+		b.WriteString("\n✅ 開催決定！時間になったら参加者にお知らせします。")
+	} else {
+		fmt.Fprintf(&b, "\n%d人集まったら開催決定です。下のボタンで参加表明してください。", flexNightQuorum)
+	}
+	return b.String()
+}
+
+func flexNightButtons() []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+		discordgo.Button{CustomID: "flexnight:join", Label: "参加", Style: discordgo.SuccessButton},
+		discordgo.Button{CustomID: "flexnight:leave", Label: "不参加", Style: discordgo.SecondaryButton},
+	}}}
+}
+
+// handleFlexNightComponent tallies a join/leave press, updates the poll
+// message in place and schedules the ping once the quorum is reached.
+// The worker re-reads the poll state when the ping fires, so late joins
+// and dropouts are honored.
+func (b *Bot) handleFlexNightComponent(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.MessageComponentInteractionData) error {
+	ephemeral := func(content string) error {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: content,
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+	state, err := b.store.InteractionState(i.Message.ID)
+	if err != nil {
+		return err
+	}
+	if state == nil || state.Kind != "flexnight" {
+		return ephemeral("この募集は期限切れです。もう一度コマンドを実行してください。")
+	}
+	var fs flexState
+	if err := json.Unmarshal([]byte(state.Payload), &fs); err != nil {
+		return err
+	}
+	at := time.Unix(fs.At, 0)
+	if time.Now().After(at) {
+		return ephemeral("この募集の開催時刻は過ぎています。")
+	}
+	userID := i.Member.User.ID
+	users := fs.Users[:0:0]
+	for _, id := range fs.Users {
+		if id != userID {
+			users = append(users, id)
+		}
+	}
+	if data.CustomID == "flexnight:join" {
+		users = append(users, userID)
+	}
+	fs.Users = users
+	if !fs.Scheduled && len(fs.Users) >= flexNightQuorum {
+		ping, err := json.Marshal(map[string]string{
+			"message_id": i.Message.ID,
+			"channel_id": fs.ChannelID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := b.store.EnqueueTaskAt(storage.TaskFlexPing, string(ping), at); err != nil {
+			return err
+		}
+		fs.Scheduled = true
+	}
+	payload, err := json.Marshal(&fs)
+	if err != nil {
+		return err
+	}
+	if err := b.store.SaveInteractionState(i.Message.ID, i.GuildID, "flexnight", string(payload)); err != nil {
+		return err
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    flexNightText(at.In(render.JST), &fs),
+			Components: flexNightButtons(),
+		},
+	})
+}
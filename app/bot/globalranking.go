@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// globalRankingRows caps the cross-guild leaderboard length.
+const globalRankingRows = 20
+
+// cmdGlobalRanking shows the leaderboard across every opted-in guild.
+// `on` / `off` (admin) control this guild's participation.
+func cmdGlobalRanking(ctx *Context) error {
+	if len(ctx.Args) >= 1 && (ctx.Args[0] == "on" || ctx.Args[0] == "off") {
+		admin, err := ctx.isAdmin()
+		if err != nil {
+			return err
+		}
+		if !admin {
+			return ctx.Reply("参加設定はサーバー管理者のみ変更できます。")
+		}
+		if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingGlobalOptIn, ctx.Args[0]); err != nil {
+			return err
+		}
+		if ctx.Args[0] == "on" {
+			return ctx.Reply("このサーバーのロスターを全体ランキングに公開します。")
+		}
+		return ctx.Reply("全体ランキングへの参加を解除しました。")
+	}
+
+	optin, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingGlobalOptIn, "off")
+	if err != nil {
+		return err
+	}
+	if optin != "on" {
+		return ctx.Reply("このサーバーは全体ランキングに参加していません。`" +
+			ctx.Bot.cfg.Prefix + "globalranking on` で参加できます（管理者のみ）。")
+	}
+	players, err := ctx.Bot.store.GlobalRanks(riotapi.QueueSolo)
+	if err != nil {
+		return err
+	}
+	players = dedupeByPUUID(players)
+	now := time.Now()
+	ranked := players[:0]
+	for _, p := range players {
+		if p.Tier != "" && !p.IsPaused(now) {
+			ranked = append(ranked, p)
+		}
+	}
+	if len(ranked) == 0 {
+		return ctx.Reply("全体ランキングに表示できるプレイヤーがまだいません。")
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return rank.Value(ranked[i].Tier, ranked[i].Division, ranked[i].LP) >
+			rank.Value(ranked[j].Tier, ranked[j].Division, ranked[j].LP)
+	})
+	if len(ranked) > globalRankingRows {
+		ranked = ranked[:globalRankingRows]
+	}
+
+	var b strings.Builder
+	b.WriteString("**全体ランキング** (参加サーバー合算)\n```\n")
+	for i, p := range ranked {
+		fmt.Fprintf(&b, "%2d. %-24s %-18s @%s\n", i+1, p.RiotID(),
+			rank.Format(p.Tier, p.Division, p.LP), ctx.guildName(p.GuildID))
+	}
+	b.WriteString("```")
+	return ctx.Reply(b.String())
+}
+
+// dedupeByPUUID keeps one entry per account when the same PUUID is
+// registered in several opted-in guilds, preferring the first guild
+// that registered it.
+func dedupeByPUUID(players []storage.RankedPlayer) []storage.RankedPlayer {
+	seen := make(map[string]bool, len(players))
+	out := players[:0]
+	for _, p := range players {
+		if seen[p.PUUID] {
+			continue
+		}
+		seen[p.PUUID] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// guildName resolves a guild's display name for attribution, falling
+// back to the raw ID when the bot cannot see the guild.
+func (c *Context) guildName(guildID string) string {
+	if g, err := c.Session.State.Guild(guildID); err == nil && g.Name != "" {
+		return g.Name
+	}
+	if g, err := c.Session.Guild(guildID); err == nil && g.Name != "" {
+		return g.Name
+	}
+	return guildID
+}
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdGoal lets a linked player set (or review) their season rank goal.
+// The worker celebrates in the announcement channel when it is reached.
+func cmdGoal(ctx *Context) error {
+	accounts, err := ctx.Bot.store.PlayersByOwner(ctx.GuildID(), ctx.Message.Author.ID)
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return ctx.Reply("先に `" + ctx.Bot.cfg.Prefix + "me link <ゲーム名#タグ>` でアカウントをリンクしてください。")
+	}
+	season := storage.CurrentSeason()
+
+	if len(ctx.Args) == 0 {
+		var b strings.Builder
+		found := false
+		for i := range accounts {
+			goal, err := ctx.Bot.store.GoalFor(accounts[i].ID, season)
+			if err != nil {
+				return err
+			}
+			if goal == nil {
+				continue
+			}
+			found = true
+			b.WriteString(goalProgressLine(ctx, &accounts[i], goal))
+		}
+		if !found {
+			return ctx.Reply("今シーズンの目標はまだ設定されていません。`" + ctx.Bot.cfg.Prefix + "goal <ティア> [ディビジョン]` で設定できます。")
+		}
+		return ctx.Reply("**今シーズンの目標**\n" + strings.TrimRight(b.String(), "\n"))
+	}
+
+	tier, ok := rank.NormalizeTier(ctx.Args[0])
+	if !ok {
+		return ctx.Reply("ティアは iron〜challenger で指定してください。")
+	}
+	division := "IV"
+	if len(ctx.Args) >= 2 {
+		if division, ok = rank.NormalizeDivision(ctx.Args[1]); !ok {
+			return ctx.Reply("ディビジョンは 1〜4 (I〜IV) で指定してください。")
+		}
+	}
+	for i := range accounts {
+		if err := ctx.Bot.store.SetGoal(accounts[i].ID, season, tier, division); err != nil {
+			return err
+		}
+	}
+	return ctx.Reply(fmt.Sprintf("今シーズンの目標を **%s %s** に設定しました。達成したらお知らせします！", tier, division))
+}
+
+// goalProgressLine renders one account's progress toward its goal.
+func goalProgressLine(ctx *Context, p *storage.Player, goal *storage.Goal) string {
+	target := fmt.Sprintf("%s %s", goal.Tier, goal.Division)
+	if !goal.AchievedAt.IsZero() {
+		return fmt.Sprintf("・%s: %s ✅ 達成済み\n", p.RiotID(), target)
+	}
+	snap, err := ctx.Bot.store.LatestSnapshot(p.ID, riotapi.QueueSolo)
+	if err != nil || snap == nil {
+		return fmt.Sprintf("・%s: %s\n", p.RiotID(), target)
+	}
+	gap := rank.Value(goal.Tier, goal.Division, 0) - rank.Value(snap.Tier, snap.Division, snap.LP)
+	if gap <= 0 {
+		return fmt.Sprintf("・%s: %s ✅ 達成済み\n", p.RiotID(), target)
+	}
+	return fmt.Sprintf("・%s: %s まであと%dLP相当 (現在 %s)\n",
+		p.RiotID(), target, gap, rank.Format(snap.Tier, snap.Division, snap.LP))
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// groupActivityDays is the contribution-graph window.
+const groupActivityDays = 90
+
+// cmdGroupActivity posts a GitHub-style graph of the group's ranked
+// games per day over the last 90 days.
+func cmdGroupActivity(ctx *Context) error {
+	to := time.Now().In(render.JST)
+	from := to.AddDate(0, 0, -(groupActivityDays - 1))
+	perDay, err := ctx.Bot.store.RankedGamesPerDay(ctx.GuildID(), riotapi.QueueIDSolo, from)
+	if err != nil {
+		return err
+	}
+	if len(perDay) == 0 {
+		return ctx.Reply("この期間のランク戦の記録がまだありません。")
+	}
+	img, err := render.ActivityGraph(perDay, from, to)
+	if err != nil {
+		return err
+	}
+	total := 0
+	bestDay, bestCount := "", 0
+	for day, n := range perDay {
+		total += n
+		if n > bestCount {
+			bestDay, bestCount = day, n
+		}
+	}
+	_, err = ctx.Session.ChannelMessageSendComplex(ctx.Message.ChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("**グループ活動** (直近%d日): 合計 %d試合 ／ 最多 %s の%d試合",
+			groupActivityDays, total, bestDay, bestCount),
+		Files: []*discordgo.File{{
+			Name:        "activity.png",
+			ContentType: "image/png",
+			Reader:      bytes.NewReader(img),
+		}},
+	})
+	return err
+}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// groupLPDefaultWeeks is the chart window when no argument is given;
+// groupLPMaxWeeks keeps the image within Discord-friendly widths.
+const (
+	groupLPDefaultWeeks = 4
+	groupLPMaxWeeks     = 26
+)
+
+// cmdGroupLP charts the sum of every registered player's LP-equivalent
+// score per day, visualizing the group's collective climb.
+func cmdGroupLP(ctx *Context) error {
+	weeks := groupLPDefaultWeeks
+	if len(ctx.Args) >= 1 {
+		n, err := strconv.Atoi(ctx.Args[0])
+		if err != nil || n < 1 {
+			return errUsage("週数は1以上の数字で指定してください。例: `grouplp 8`")
+		}
+		weeks = n
+		if weeks > groupLPMaxWeeks {
+			weeks = groupLPMaxWeeks
+		}
+	}
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	days := weeks * 7
+	today := time.Now().In(render.JST)
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, render.JST).AddDate(0, 0, -(days - 1))
+
+	// Sum each player's daily value, carrying the last reading forward
+	// over days without a snapshot. Days before a player's first
+	// snapshot simply don't include them, so the line climbs as the
+	// roster fills in — same as the group actually did.
+	totals := make([]int, days)
+	any := false
+	for i := range players {
+		snaps, err := ctx.Bot.store.DailySnapshots(players[i].ID, riotapi.QueueSolo, start)
+		if err != nil {
+			return err
+		}
+		if len(snaps) == 0 {
+			continue
+		}
+		any = true
+		next, current := 0, -1
+		for d := 0; d < days; d++ {
+			dayEnd := start.AddDate(0, 0, d+1)
+			for next < len(snaps) && snaps[next].TakenAt.Before(dayEnd) {
+				current = rank.Value(snaps[next].Tier, snaps[next].Division, snaps[next].LP)
+				next++
+			}
+			if current >= 0 {
+				totals[d] += current
+			}
+		}
+	}
+	if !any {
+		return ctx.Reply("この期間のスナップショットがまだありません。")
+	}
+
+	img, err := render.LineGraph(totals)
+	if err != nil {
+		return err
+	}
+	delta := totals[days-1] - totals[0]
+	sign := "+"
+	if delta < 0 {
+		sign = ""
+	}
+	_, err = ctx.Session.ChannelMessageSendComplex(ctx.Message.ChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("**グループ合計LP換算** (直近%d週): 現在 %d ／ 期間中 %s%d",
+			weeks, totals[days-1], sign, delta),
+		Files: []*discordgo.File{{
+			Name:        "grouplp.png",
+			ContentType: "image/png",
+			Reader:      bytes.NewReader(img),
+		}},
+	})
+	return err
+}
@@ -0,0 +1,123 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// groupTierWindowDays is the aggregation window for !grouptier and the
+// champion-based helpers built on it; groupTierMinGames is the floor
+// for winrate rankings so a single lucky game doesn't top the list.
+const (
+	groupTierWindowDays = 30
+	groupTierMinGames   = 5
+)
+
+// champStats aggregates the group's games on one champion.
+type champStats struct {
+	name  string
+	games int
+	wins  int
+}
+
+func (c *champStats) winrate() float64 {
+	if c.games == 0 {
+		return 0
+	}
+	return float64(c.wins) / float64(c.games) * 100
+}
+
+// groupChampionStats aggregates every registered player's recent
+// solo-queue games per champion.
+func groupChampionStats(ctx *Context, from, to time.Time) ([]*champStats, error) {
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return nil, err
+	}
+	agg := map[string]*champStats{}
+	for i := range players {
+		matches, err := ctx.Bot.store.MatchesForPlayer(players[i].ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if m.Info.QueueID != riotapi.QueueIDSolo {
+				continue
+			}
+			for _, part := range m.Info.Participants {
+				if part.PUUID != players[i].PUUID {
+					continue
+				}
+				c := agg[part.ChampionName]
+				if c == nil {
+					c = &champStats{name: part.ChampionName}
+					agg[part.ChampionName] = c
+				}
+				c.games++
+				if part.Win {
+					c.wins++
+				}
+				break
+			}
+		}
+	}
+	out := make([]*champStats, 0, len(agg))
+	for _, c := range agg {
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// cmdGroupTier shows the group's most-played and best-performing
+// champions over the last 30 days.
+func cmdGroupTier(ctx *Context) error {
+	to := time.Now()
+	stats, err := groupChampionStats(ctx, to.AddDate(0, 0, -groupTierWindowDays), to)
+	if err != nil {
+		return err
+	}
+	if len(stats) == 0 {
+		return ctx.Reply(fmt.Sprintf("直近%d日の試合データがまだありません。", groupTierWindowDays))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**グループ チャンピオン傾向** (直近%d日)\n", groupTierWindowDays)
+
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].games > stats[j].games })
+	b.WriteString("```\nよく使うチャンピオン\n")
+	for i, c := range stats {
+		if i == 10 {
+			break
+		}
+		fmt.Fprintf(&b, "%2d. %-14s %3d試合  勝率%3.0f%%\n", i+1, c.name, c.games, c.winrate())
+	}
+
+	best := make([]*champStats, 0, len(stats))
+	for _, c := range stats {
+		if c.games >= groupTierMinGames {
+			best = append(best, c)
+		}
+	}
+	if len(best) > 0 {
+		sort.SliceStable(best, func(i, j int) bool { return best[i].winrate() > best[j].winrate() })
+		fmt.Fprintf(&b, "\n勝率が高いチャンピオン (%d試合以上)\n", groupTierMinGames)
+		for i, c := range best {
+			if i == 5 {
+				break
+			}
+			fmt.Fprintf(&b, "%2d. %-14s 勝率%3.0f%% (%d試合)\n", i+1, c.name, c.winrate(), c.games)
+		}
+	}
+	b.WriteString("```")
+	if at, err := ctx.Bot.store.GuildMatchFetch(ctx.GuildID()); err == nil {
+		if footer := render.FreshnessFooter(time.Time{}, at); footer != "" {
+			b.WriteString("\n" + footer)
+		}
+	}
+	return ctx.Reply(b.String())
+}
@@ -0,0 +1,138 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// importMax caps one !import run so a huge list cannot monopolize the
+// Riot API budget; larger rosters go in several batches.
+const importMax = 30
+
+// attachmentClient fetches files users attach to an !import message.
+var attachmentClient = &http.Client{Timeout: 15 * time.Second}
+
+// cmdImport registers many players at once from an attached CSV/JSON
+// file or a multi-line list of Riot IDs in the message itself.
+func cmdImport(ctx *Context) error {
+	text := strings.Join(ctx.Args, " ")
+	if len(ctx.Message.Attachments) > 0 {
+		body, err := fetchAttachment(ctx.Message.Attachments[0].URL)
+		if err != nil {
+			return ctx.Reply("添付ファイルを読み込めませんでした。")
+		}
+		text = body
+	}
+	entries := splitImportEntries(text)
+	if len(entries) == 0 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "import` にRiot IDのリスト（改行・カンマ区切り、またはCSV/JSON添付）を付けてください。")
+	}
+	return addMany(ctx, entries)
+}
+
+// addMany registers a batch of Riot IDs, validating each against the
+// Account API and summarizing successes and failures.
+func addMany(ctx *Context, entries []string) error {
+	if len(entries) > importMax {
+		return ctx.Reply(fmt.Sprintf("一度に登録できるのは%d件までです（%d件指定されました）。分割してやり直してください。", importMax, len(entries)))
+	}
+	var b strings.Builder
+	added, existing, failed := 0, 0, 0
+	for i, entry := range entries {
+		if ctx.expired() {
+			// Budget spent: report what went through rather than keep
+			// the channel waiting on the remaining lookups.
+			fmt.Fprintf(&b, "⏱ 時間切れのため残り%d件は処理されませんでした。再度実行してください。\n", len(entries)-i)
+			break
+		}
+		gameName, tagLine, ok := parseRiotID(entry)
+		if !ok {
+			failed++
+			fmt.Fprintf(&b, "❌ %s — Riot IDの形式ではありません\n", entry)
+			continue
+		}
+		player, wasAdded, err := registerRiotID(ctx, gameName, tagLine)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(&b, "❌ %s — %s\n", entry, userMessage(err))
+		case wasAdded:
+			added++
+			fmt.Fprintf(&b, "✅ %s\n", player.RiotID())
+		default:
+			existing++
+			fmt.Fprintf(&b, "⏭ %s — 登録済み\n", player.RiotID())
+		}
+	}
+	fmt.Fprintf(&b, "\n登録 %d / 既存 %d / 失敗 %d", added, existing, failed)
+	return ctx.Reply(b.String())
+}
+
+// opggMultiIDs extracts Riot IDs from an op.gg multi-search URL.
+func opggMultiIDs(s string) ([]string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "op.gg/multi") {
+		return nil, false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, false
+	}
+	raw := u.Query().Get("summoners")
+	if raw == "" {
+		return nil, false
+	}
+	ids := trimEntries(strings.Split(raw, ","))
+	return ids, len(ids) > 0
+}
+
+// splitImportEntries extracts Riot ID candidates from a pasted list,
+// CSV body or JSON string array.
+func splitImportEntries(text string) []string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```") {
+		text = strings.Trim(text, "`")
+		text = strings.TrimPrefix(text, "json")
+	}
+	if strings.HasPrefix(strings.TrimSpace(text), "[") {
+		var ids []string
+		if err := json.Unmarshal([]byte(text), &ids); err == nil {
+			return trimEntries(ids)
+		}
+	}
+	return trimEntries(strings.FieldsFunc(text, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ','
+	}))
+}
+
+// trimEntries drops blanks and anything without the # separator, so
+// CSV headers and stray cells don't count as failures.
+func trimEntries(in []string) []string {
+	var out []string
+	for _, s := range in {
+		s = strings.TrimSpace(strings.Trim(strings.TrimSpace(s), `"`))
+		if s == "" || !strings.Contains(s, "#") {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func fetchAttachment(url string) (string, error) {
+	resp, err := attachmentClient.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("attachment fetch: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	return string(body), err
+}
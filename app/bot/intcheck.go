@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// intCheckDefaultDays is the default !intcheck window; intCheckMaxDays
+// keeps the window inside the match cache retention.
+const (
+	intCheckDefaultDays = 7
+	intCheckMaxDays     = 30
+)
+
+// cmdIntCheck is the tongue-in-cheek deaths-per-game leaderboard. It is
+// off by default and enabled per guild, for groups that enjoy friendly
+// flaming.
+func cmdIntCheck(ctx *Context) error {
+	if len(ctx.Args) >= 1 && (ctx.Args[0] == "on" || ctx.Args[0] == "off") {
+		return setIntCheck(ctx, ctx.Args[0])
+	}
+	enabled, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingIntCheck, "off")
+	if err != nil {
+		return err
+	}
+	if enabled != "on" {
+		return ctx.Reply("このサーバーでは `" + ctx.Bot.cfg.Prefix + "intcheck` は無効です。管理者が `" +
+			ctx.Bot.cfg.Prefix + "intcheck on` で有効にできます。")
+	}
+
+	days := intCheckDefaultDays
+	if len(ctx.Args) >= 1 {
+		parsed, err := strconv.Atoi(ctx.Args[0])
+		if err != nil || parsed < 1 || parsed > intCheckMaxDays {
+			return ctx.Reply(fmt.Sprintf("日数は 1〜%d で指定してください。", intCheckMaxDays))
+		}
+		days = parsed
+	}
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	type row struct {
+		name          string
+		deathsPerGame float64
+		kda           float64
+		games         int
+	}
+	var rows []row
+	for i := range players {
+		st, err := statsForPlayer(ctx.Bot.store, &players[i], from, to)
+		if err != nil {
+			return err
+		}
+		if st.games < compareMinGames {
+			continue
+		}
+		rows = append(rows, row{
+			name:          players[i].RiotID(),
+			deathsPerGame: float64(st.deaths) / float64(st.games),
+			kda:           st.kda(),
+			games:         st.games,
+		})
+	}
+	if len(rows) == 0 {
+		return ctx.Reply("集計できるだけの試合データがありません。")
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].deathsPerGame > rows[j].deathsPerGame })
+	worstKDA := 0
+	for i, r := range rows {
+		if r.kda < rows[worstKDA].kda {
+			worstKDA = i
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**イントチェック** (直近%d日) 💀\n```\n", days)
+	for i, r := range rows {
+		mark := " "
+		if i == worstKDA {
+			mark = "👑" // worst KDA crown
+		}
+		fmt.Fprintf(&b, "%2d. %-24s %.1fデス/試合  KDA %.2f (%d試合) %s\n",
+			i+1, r.name, r.deathsPerGame, r.kda, r.games, mark)
+	}
+	b.WriteString("```\n※ あくまでネタ企画です。仲良く。")
+	return ctx.Reply(b.String())
+}
+
+// setIntCheck toggles the feature for the guild (admin only).
+func setIntCheck(ctx *Context, value string) error {
+	admin, err := ctx.isAdmin()
+	if err != nil {
+		return err
+	}
+	if !admin {
+		return ctx.Reply("有効/無効の切り替えはサーバー管理者のみ行えます。")
+	}
+	if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingIntCheck, value); err != nil {
+		return err
+	}
+	if value == "on" {
+		return ctx.Reply("`" + ctx.Bot.cfg.Prefix + "intcheck` を有効にしました。ほどほどに。")
+	}
+	return ctx.Reply("`" + ctx.Bot.cfg.Prefix + "intcheck` を無効にしました。")
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdLive detects the live game a player is in and scouts all ten
+// participants: everyone's solo rank, per-team averages and the most
+// dangerous player on each side.
+func cmdLive(ctx *Context) error {
+	puuid, platform, name, err := liveTarget(ctx)
+	if err != nil {
+		return err
+	}
+	if puuid == "" {
+		return nil // liveTarget already replied with usage
+	}
+	riot := ctx.Bot.riot.ForPlatform(platform)
+
+	game, err := riot.ActiveGameByPUUID(puuid)
+	if errors.Is(err, riotapi.ErrNotFound) {
+		return ctx.Reply(fmt.Sprintf("**%s** は現在試合中ではありません。", name))
+	}
+	if err != nil {
+		return err
+	}
+
+	// The ten league lookups run concurrently; the shared limiter still
+	// spaces the actual requests, so this stays inside the key's budget
+	// while overlapping with Discord round-trips.
+	results := make([]liveScout, len(game.Participants))
+	errs := make([]error, len(game.Participants))
+	var wg sync.WaitGroup
+	for i := range game.Participants {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p := game.Participants[i]
+			results[i] = liveScout{part: p, value: -1, rank: "ランクなし"}
+			entries, err := riot.LeagueEntriesBySummonerID(p.SummonerID)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for _, e := range entries {
+				if e.QueueType == riotapi.QueueSolo {
+					results[i].value = rank.Value(e.Tier, e.Rank, e.LeaguePoints)
+					results[i].rank = rank.Format(e.Tier, e.Rank, e.LeaguePoints)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s の試合** (経過 %d分)\n", name, game.GameLength/60)
+	for _, teamID := range []int{100, 200} {
+		var team []liveScout
+		for _, r := range results {
+			if r.part.TeamID == teamID {
+				team = append(team, r)
+			}
+		}
+		if len(team) == 0 {
+			continue
+		}
+		sort.Slice(team, func(i, j int) bool { return team[i].value > team[j].value })
+		side := "ブルーサイド"
+		if teamID == 200 {
+			side = "レッドサイド"
+		}
+		fmt.Fprintf(&b, "\n__%s__ (平均 %s)\n```\n", side, teamAverage(team))
+		for i, s := range team {
+			marker := "  "
+			if i == 0 && s.value >= 0 {
+				marker = "⚠ "
+			}
+			champ := ctx.Bot.champions.ChampionName(s.part.ChampionID)
+			fmt.Fprintf(&b, "%s%-24s %-14s %s\n", marker, s.part.RiotID, champ, s.rank)
+		}
+		b.WriteString("```")
+	}
+	return ctx.Reply(b.String())
+}
+
+// liveScout is one live-game participant with their resolved solo rank.
+type liveScout struct {
+	part  riotapi.CurrentGameParticipantDTO
+	value int
+	rank  string
+}
+
+// teamAverage renders the mean rank of a team's ranked members.
+func teamAverage(team []liveScout) string {
+	sum, n := 0, 0
+	for _, s := range team {
+		if s.value >= 0 {
+			sum += s.value
+			n++
+		}
+	}
+	if n == 0 {
+		return "ランクなし"
+	}
+	tier, division, lp := rank.FromValue(sum / n)
+	return rank.Format(tier, division, lp)
+}
+
+// liveTarget resolves who to spectate: an explicit riot ID argument, or
+// the caller's own linked account when invoked bare.
+func liveTarget(ctx *Context) (puuid, platform, name string, err error) {
+	if len(ctx.Args) == 0 {
+		accounts, err := ctx.Bot.store.PlayersByOwner(ctx.GuildID(), ctx.Message.Author.ID)
+		if err != nil {
+			return "", "", "", err
+		}
+		if len(accounts) == 0 {
+			return "", "", "", ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "live [ゲーム名#タグ]`（引数なしは自分のリンク済みアカウント）")
+		}
+		p := accounts[0]
+		return p.PUUID, p.Platform, p.RiotID(), nil
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return "", "", "", ctx.Reply("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	p, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if err == nil {
+		return p.PUUID, p.Platform, p.RiotID(), nil
+	}
+	if !errors.Is(err, storage.ErrNotRegistered) {
+		return "", "", "", err
+	}
+	account, err := ctx.Bot.riot.AccountByRiotID(gameName, tagLine)
+	if errors.Is(err, riotapi.ErrNotFound) {
+		return "", "", "", ctx.Reply(fmt.Sprintf("**%s#%s** が見つかりませんでした。", gameName, tagLine))
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+	if platform, err = detectPlatform(ctx, account.PUUID); err != nil {
+		return "", "", "", err
+	}
+	return account.PUUID, platform, account.GameName + "#" + account.TagLine, nil
+}
@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdMaintenance toggles instance-wide maintenance mode: the worker
+// skips its scheduled jobs and the gateway answers every non-operator
+// command with a maintenance notice.
+func cmdMaintenance(ctx *Context) error {
+	if len(ctx.Args) >= 1 && (ctx.Args[0] == "on" || ctx.Args[0] == "off") {
+		if err := ctx.Bot.store.SetAppSetting(storage.AppSettingMaintenance, ctx.Args[0]); err != nil {
+			return err
+		}
+		if ctx.Args[0] == "on" {
+			return ctx.Reply("🔧 メンテナンスモードを開始しました。定期処理とコマンドは停止します。")
+		}
+		return ctx.Reply("✅ メンテナンスモードを終了しました。")
+	}
+	mode, err := ctx.Bot.store.AppSetting(storage.AppSettingMaintenance, "off")
+	if err != nil {
+		return err
+	}
+	state := "オフ"
+	if mode == "on" {
+		state = "オン"
+	}
+	return ctx.Reply("メンテナンスモードは現在 **" + state + "** です。`" + ctx.Bot.cfg.Prefix +
+		"maintenance on|off` で切り替えられます。")
+}
+
+// cmdCachePurge drops the gateway's in-memory caches so the next
+// invocation of every command re-renders from the database.
+func cmdCachePurge(ctx *Context) error {
+	replies := ctx.Bot.replies.purge()
+	scoutCache.Lock()
+	scouts := len(scoutCache.m)
+	scoutCache.m = map[string]scoutEntry{}
+	scoutCache.Unlock()
+	return ctx.Reply(fmt.Sprintf("キャッシュを削除しました（返信 %d件、スカウト %d件）。", replies, scouts))
+}
+
+// cmdResync overwrites the application's registered slash commands with
+// the current definitions, for when Discord's view has drifted.
+func cmdResync(ctx *Context) error {
+	cmds := ctx.Bot.applicationCommands()
+	if _, err := ctx.Session.ApplicationCommandBulkOverwrite(ctx.Session.State.User.ID, "", cmds); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("スラッシュコマンドを再登録しました（%d件）。", len(cmds)))
+}
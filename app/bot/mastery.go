@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// masteryTop is how many of a player's highest-mastery champions the
+// command lists.
+const masteryTop = 5
+
+// cmdMastery shows a player's top champion masteries: level and points
+// per champion, names resolved through the Data Dragon dataset.
+func cmdMastery(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		return errUsage("使い方: `" + ctx.Bot.cfg.Prefix + "mastery <ゲーム名#タグ>`")
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return errUsage("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	var puuid, platform, name string
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	switch {
+	case err == nil:
+		puuid, platform, name = player.PUUID, player.Platform, player.RiotID()
+	case errors.Is(err, storage.ErrNotRegistered):
+		account, err := ctx.Bot.riot.AccountByRiotID(gameName, tagLine)
+		if errors.Is(err, riotapi.ErrNotFound) {
+			return ctx.Reply(fmt.Sprintf("**%s#%s** が見つかりませんでした。", gameName, tagLine))
+		}
+		if err != nil {
+			return err
+		}
+		if platform, err = detectPlatform(ctx, account.PUUID); err != nil {
+			return err
+		}
+		puuid, name = account.PUUID, account.GameName+"#"+account.TagLine
+	default:
+		return err
+	}
+
+	riot := ctx.Bot.riot.ForPlatform(platform)
+	masteries, err := riot.ChampionMasteriesTopByPUUID(puuid, masteryTop)
+	if err != nil {
+		return err
+	}
+	if len(masteries) == 0 {
+		return ctx.Reply(fmt.Sprintf("**%s** のチャンピオンマスタリーが見つかりませんでした。", name))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s のチャンピオンマスタリー**\n```\n", name)
+	for i, m := range masteries {
+		fmt.Fprintf(&b, "%d. %-14s Lv%-2d %dpt\n",
+			i+1, ctx.Bot.champions.ChampionName(m.ChampionID), m.ChampionLevel, m.ChampionPoints)
+	}
+	b.WriteString("```")
+	return ctx.Reply(b.String())
+}
@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+func cmdMatch(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "match <試合ID>` または `" +
+			ctx.Bot.cfg.Prefix + "match latest <ゲーム名#タグ>`")
+	}
+
+	var m *riotapi.MatchDTO
+	var err error
+	if strings.EqualFold(ctx.Args[0], "latest") {
+		m, err = latestMatchFor(ctx, strings.Join(ctx.Args[1:], " "))
+	} else {
+		m, err = matchByID(ctx, ctx.Args[0])
+	}
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return ctx.Reply("試合が見つかりませんでした。")
+	}
+
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	highlight := make(map[string]bool, len(players))
+	for _, p := range players {
+		highlight[p.PUUID] = true
+	}
+	loc, err := guildLocale(ctx)
+	if err != nil {
+		return err
+	}
+	return ctx.Reply(render.MatchReport(m, highlight, loc))
+}
+
+// matchByID serves a match from the cache, fetching and caching it on a
+// miss.
+func matchByID(ctx *Context, matchID string) (*riotapi.MatchDTO, error) {
+	m, err := ctx.Bot.store.MatchByID(matchID)
+	if err != nil || m != nil {
+		return m, err
+	}
+	m, err = ctx.Bot.riot.Match(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Bot.store.CacheMatch(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// latestMatchFor finds a registered player's most recent game,
+// preferring the cache over an API round trip.
+func latestMatchFor(ctx *Context, riotID string) (*riotapi.MatchDTO, error) {
+	gameName, tagLine, ok := parseRiotID(riotID)
+	if !ok {
+		return nil, errUsage("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if err == storage.ErrNotRegistered {
+		return nil, errUsage("そのプレイヤーは登録されていません。")
+	}
+	if err != nil {
+		return nil, err
+	}
+	cached, err := ctx.Bot.store.RecentMatchesForPlayer(player.ID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(cached) > 0 {
+		return &cached[0], nil
+	}
+	riot := ctx.Bot.riot.ForPlatform(player.Platform)
+	ids, err := riot.MatchIDsByPUUID(player.PUUID, 0, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	m, err := riot.Match(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Bot.store.SaveMatch(player.ID, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
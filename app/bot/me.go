@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdMe lists every account the calling user has linked — possibly
+// across regions — and marks the best one, which is the account that
+// represents them on person-level leaderboards.
+func cmdMe(ctx *Context) error {
+	if len(ctx.Args) >= 1 && ctx.Args[0] == "link" {
+		return linkAccount(ctx)
+	}
+	accounts, err := ctx.Bot.store.PlayersByOwner(ctx.GuildID(), ctx.Message.Author.ID)
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return ctx.Reply("リンク済みのアカウントがありません。`" + ctx.Bot.cfg.Prefix +
+			"me link <ゲーム名#タグ> [プラットフォーム]` でリンクしてください。")
+	}
+
+	type line struct {
+		text  string
+		value int
+	}
+	lines := make([]line, 0, len(accounts))
+	bestIdx, bestValue := -1, -1
+	partial := false
+	for _, p := range accounts {
+		if ctx.expired() {
+			partial = true
+			break
+		}
+		// Route league lookups to each account's own platform.
+		entries, err := ctx.Bot.riot.ForPlatform(p.Platform).LeagueEntriesBySummonerID(p.SummonerID)
+		if err != nil {
+			return err
+		}
+		display := "ランクなし"
+		value := -1
+		for _, e := range entries {
+			if e.QueueType == riotapi.QueueSolo {
+				display = rank.Format(e.Tier, e.Rank, e.LeaguePoints)
+				value = rank.Value(e.Tier, e.Rank, e.LeaguePoints)
+			}
+		}
+		if value > bestValue {
+			bestValue, bestIdx = value, len(lines)
+		}
+		lines = append(lines, line{
+			text:  fmt.Sprintf("%-5s %-24s %s", p.Platform, p.RiotID(), display),
+			value: value,
+		})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s のアカウント**\n```\n", ctx.Message.Author.Username)
+	for i, l := range lines {
+		marker := "  "
+		if i == bestIdx {
+			marker = "★ "
+		}
+		b.WriteString(marker + l.text + "\n")
+	}
+	b.WriteString("```\nランキングには★のアカウントが使われます。")
+	if partial {
+		fmt.Fprintf(&b, "\n⏱ 時間切れのため%d件のアカウントは未取得です。", len(accounts)-len(lines))
+	}
+	season := storage.CurrentSeason()
+	for i := range accounts {
+		goal, err := ctx.Bot.store.GoalFor(accounts[i].ID, season)
+		if err != nil {
+			return err
+		}
+		if goal != nil {
+			b.WriteString("\n" + strings.TrimRight(goalProgressLine(ctx, &accounts[i], goal), "\n"))
+		}
+	}
+	if bestIdx >= 0 {
+		to := time.Now()
+		st, err := statsForPlayer(ctx.Bot.store, &accounts[bestIdx], to.AddDate(0, 0, -compareWindowDays), to)
+		if err != nil {
+			return err
+		}
+		if ff := neverFFLine(st); ff != "" {
+			b.WriteString("\n" + ff)
+		}
+		cmp, err := groupComparison(ctx, &accounts[bestIdx])
+		if err != nil {
+			return err
+		}
+		if cmp != "" {
+			b.WriteString("\n" + cmp)
+		}
+	}
+	return ctx.Reply(b.String())
+}
+
+// linkAccount handles `!me link <gameName#tagLine> [platform]`,
+// registering the account if needed and claiming it for the caller.
+func linkAccount(ctx *Context) error {
+	args := ctx.Args[1:]
+	platform := ""
+	if len(args) >= 2 && riotapi.ValidPlatform(args[len(args)-1]) {
+		platform = strings.ToLower(args[len(args)-1])
+		args = args[:len(args)-1]
+	}
+	if len(args) == 0 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "me link <ゲーム名#タグ> [プラットフォーム]` 例: `" +
+			ctx.Bot.cfg.Prefix + "me link Faker#KR1 kr`")
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(args, " "))
+	if !ok {
+		return ctx.Reply("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+
+	account, err := ctx.Bot.riot.AccountByRiotID(gameName, tagLine)
+	if err != nil {
+		return err
+	}
+	if platform == "" {
+		// No explicit region: let the active-shard lookup decide.
+		if platform, err = detectPlatform(ctx, account.PUUID); err != nil {
+			return err
+		}
+	}
+	summoner, err := ctx.Bot.riot.ForPlatform(platform).SummonerByPUUID(account.PUUID)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Bot.store.EnsureGuild(ctx.GuildID()); err != nil {
+		return err
+	}
+	player := &storage.Player{
+		GuildID:       ctx.GuildID(),
+		GameName:      account.GameName,
+		TagLine:       account.TagLine,
+		PUUID:         account.PUUID,
+		SummonerID:    summoner.ID,
+		Platform:      platform,
+		DiscordUserID: ctx.Message.Author.ID,
+	}
+	added, err := ctx.Bot.store.AddPlayer(player)
+	if err != nil {
+		return err
+	}
+	if !added {
+		// Already on the roster: just claim it.
+		existing, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), account.GameName, account.TagLine)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Bot.store.SetPlayerOwner(existing.ID, ctx.Message.Author.ID); err != nil {
+			return err
+		}
+		return ctx.Reply(fmt.Sprintf("**%s** をあなたのアカウントとしてリンクしました。", existing.RiotID()))
+	}
+	if err := ctx.Bot.store.EnqueueTask(storage.TaskRefreshPlayer, fmt.Sprintf("%d", player.ID)); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("**%s** (%s) を登録してリンクしました。", player.RiotID(), platform))
+}
+
+// bestPerOwner keeps only the strongest account of each linked user so
+// leaderboards count people, not accounts. Unlinked accounts pass
+// through untouched.
+func bestPerOwner(players []storage.RankedPlayer) []storage.RankedPlayer {
+	seen := map[string]int{} // owner -> index in out
+	var out []storage.RankedPlayer
+	for _, p := range players {
+		if p.DiscordUserID == "" {
+			out = append(out, p)
+			continue
+		}
+		if i, ok := seen[p.DiscordUserID]; ok {
+			if rank.Value(p.Tier, p.Division, p.LP) > rank.Value(out[i].Tier, out[i].Division, out[i].LP) {
+				out[i] = p
+			}
+			continue
+		}
+		seen[p.DiscordUserID] = len(out)
+		out = append(out, p)
+	}
+	return out
+}
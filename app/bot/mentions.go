@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdMentions lets a user choose whether recaps and promotion alerts
+// ping them. The preference is per guild; mentions are on by default.
+func cmdMentions(ctx *Context) error {
+	key := storage.SettingMentionPrefix + ctx.Message.Author.ID
+	if len(ctx.Args) >= 1 && (ctx.Args[0] == "on" || ctx.Args[0] == "off") {
+		if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), key, ctx.Args[0]); err != nil {
+			return err
+		}
+		if ctx.Args[0] == "on" {
+			return ctx.Reply("まとめ投稿であなたをメンションします。")
+		}
+		return ctx.Reply("まとめ投稿でのメンションを止めました。")
+	}
+	pref, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), key, "on")
+	if err != nil {
+		return err
+	}
+	state := "オン"
+	if pref == "off" {
+		state = "オフ"
+	}
+	return ctx.Reply("メンションは現在 **" + state + "** です。`" + ctx.Bot.cfg.Prefix +
+		"mentions on|off` で切り替えられます。")
+}
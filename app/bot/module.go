@@ -0,0 +1,43 @@
+package bot
+
+import "log"
+
+// Module is a self-contained bundle of commands that can be dropped
+// into the bot from another package. A module package registers itself
+// from init():
+//
+//	func init() { bot.RegisterModule(myModule{}) }
+//
+// and every command it returns appears in the router and in !help after
+// the built-ins, in registration order. Commands carry their own help
+// text, permissions (AdminOnly) and feature flag, so a module needs
+// nothing beyond this interface.
+type Module interface {
+	// Commands returns the commands the module provides. It is called
+	// once, while the Bot is being wired up.
+	Commands() []*Command
+}
+
+// modules are the registered extension modules, in init order.
+var modules []Module
+
+// RegisterModule adds a module's commands to every Bot built afterwards.
+// It is intended to be called from init().
+func RegisterModule(m Module) {
+	modules = append(modules, m)
+}
+
+// registerModules appends each module's commands to the router. A name
+// already taken by a built-in (or an earlier module) is skipped so a
+// plugin can never shadow core commands.
+func (b *Bot) registerModules() {
+	for _, m := range modules {
+		for _, cmd := range m.Commands() {
+			if _, taken := b.router.commands[cmd.Name]; taken {
+				log.Printf("gateway: module command %q already registered, skipping", cmd.Name)
+				continue
+			}
+			b.router.register(cmd)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// nemesisWindowDays is the aggregation window; nemesisMinLosses keeps a
+// champion off the list until it has beaten the player more than once;
+// nemesisTop caps the list.
+const (
+	nemesisWindowDays = 90
+	nemesisMinLosses  = 2
+	nemesisTop        = 3
+)
+
+// cmdNemesis shows which opposing champions beat a player most often,
+// aggregated from the cached match history.
+func cmdNemesis(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		return errUsage("使い方: `" + ctx.Bot.cfg.Prefix + "nemesis <ゲーム名#タグ>`")
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return errUsage("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if errors.Is(err, storage.ErrNotRegistered) {
+		return ctx.Reply(fmt.Sprintf("**%s#%s** は登録されていません。", gameName, tagLine))
+	}
+	if err != nil {
+		return err
+	}
+
+	to := time.Now()
+	matches, err := ctx.Bot.store.MatchesForPlayer(player.ID, to.AddDate(0, 0, -nemesisWindowDays), to)
+	if err != nil {
+		return err
+	}
+	// Per enemy champion: how often it was faced and how often the
+	// player lost to it.
+	type record struct {
+		name   string
+		faced  int
+		losses int
+	}
+	agg := map[string]*record{}
+	for _, m := range matches {
+		if m.Info.QueueID != riotapi.QueueIDSolo {
+			continue
+		}
+		teamID, won := 0, false
+		for _, p := range m.Info.Participants {
+			if p.PUUID == player.PUUID {
+				teamID, won = p.TeamID, p.Win
+				break
+			}
+		}
+		if teamID == 0 {
+			continue
+		}
+		for _, p := range m.Info.Participants {
+			if p.TeamID == teamID {
+				continue
+			}
+			r := agg[p.ChampionName]
+			if r == nil {
+				r = &record{name: p.ChampionName}
+				agg[p.ChampionName] = r
+			}
+			r.faced++
+			if !won {
+				r.losses++
+			}
+		}
+	}
+
+	nemeses := make([]*record, 0, len(agg))
+	for _, r := range agg {
+		if r.losses >= nemesisMinLosses {
+			nemeses = append(nemeses, r)
+		}
+	}
+	if len(nemeses) == 0 {
+		return ctx.Reply(fmt.Sprintf("直近%d日で **%s** の天敵と呼べるチャンピオンはいません。", nemesisWindowDays, player.RiotID()))
+	}
+	sort.SliceStable(nemeses, func(i, j int) bool {
+		if nemeses[i].losses != nemeses[j].losses {
+			return nemeses[i].losses > nemeses[j].losses
+		}
+		return nemeses[i].faced < nemeses[j].faced // fewer games for the same losses = scarier
+	})
+	if len(nemeses) > nemesisTop {
+		nemeses = nemeses[:nemesisTop]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s の天敵チャンピオン** (直近%d日)\n", player.RiotID(), nemesisWindowDays)
+	for i, r := range nemeses {
+		fmt.Fprintf(&b, "%d. %s — %d敗 (対戦%d回)\n", i+1, r.name, r.losses, r.faced)
+	}
+	text := strings.TrimRight(b.String(), "\n")
+	if footer := playerFreshness(ctx, player); footer != "" {
+		text += "\n" + footer
+	}
+	return ctx.Reply(text)
+}
@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// onGuildCreate posts the setup wizard the first time the bot joins a
+// guild. GuildCreate also fires for every known guild on connect, so
+// guilds already in storage are skipped.
+func (b *Bot) onGuildCreate(s *discordgo.Session, g *discordgo.GuildCreate) {
+	known, err := b.store.GuildKnown(g.ID)
+	if err != nil {
+		log.Printf("gateway: guild lookup for %s: %v", g.ID, err)
+		return
+	}
+	if known {
+		return
+	}
+	if err := b.store.EnsureGuild(g.ID); err != nil {
+		log.Printf("gateway: ensure guild %s: %v", g.ID, err)
+		return
+	}
+	channelID := g.SystemChannelID
+	if channelID == "" {
+		for _, ch := range g.Channels {
+			if ch.Type == discordgo.ChannelTypeGuildText {
+				channelID = ch.ID
+				break
+			}
+		}
+	}
+	if channelID == "" {
+		return
+	}
+	if _, err := s.ChannelMessageSendComplex(channelID, setupMessage(b.cfg.Prefix)); err != nil {
+		log.Printf("gateway: setup message for %s: %v", g.ID, err)
+	}
+}
+
+// setupMessage builds the interactive onboarding message.
+func setupMessage(prefix string) *discordgo.MessageSend {
+	return &discordgo.MessageSend{
+		Content: "**セットアップ**\n" +
+			"招待ありがとうございます！下のメニューで初期設定ができます。\n" +
+			"プレイヤーの登録は `" + prefix + "add <ゲーム名#タグ>` でいつでもできます。",
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    "setup:locale",
+					Placeholder: "表示言語 / Language",
+					Options: []discordgo.SelectMenuOption{
+						{Label: "日本語", Value: "ja"},
+						{Label: "English", Value: "en"},
+					},
+				},
+			}},
+			discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					CustomID: "setup:channel",
+					Label:    "このチャンネルを投稿先にする",
+					Style:    discordgo.PrimaryButton,
+				},
+			}},
+		},
+	}
+}
+
+// onInteractionCreate routes slash commands and component interactions.
+func (b *Bot) onInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		b.handleApplicationCommand(s, i)
+		return
+	case discordgo.InteractionMessageComponent:
+	default:
+		return
+	}
+	data := i.MessageComponentData()
+	switch {
+	case strings.HasPrefix(data.CustomID, "setup:"):
+		if err := b.handleSetupComponent(s, i, data); err != nil {
+			log.Printf("gateway: setup interaction: %v", err)
+		}
+	case strings.HasPrefix(data.CustomID, "scan:"):
+		if err := b.handleScanComponent(s, i, data); err != nil {
+			log.Printf("gateway: scan interaction: %v", err)
+		}
+	case strings.HasPrefix(data.CustomID, "profile:"):
+		if err := b.handleProfileComponent(s, i, data); err != nil {
+			log.Printf("gateway: profile interaction: %v", err)
+		}
+	case strings.HasPrefix(data.CustomID, "daystats:"):
+		if err := b.handleDaystatsComponent(s, i, data); err != nil {
+			log.Printf("gateway: daystats interaction: %v", err)
+		}
+	case strings.HasPrefix(data.CustomID, "flexnight:"):
+		if err := b.handleFlexNightComponent(s, i, data); err != nil {
+			log.Printf("gateway: flexnight interaction: %v", err)
+		}
+	case strings.HasPrefix(data.CustomID, "ranking:"):
+		if err := b.handleRankingComponent(s, i, data); err != nil {
+			log.Printf("gateway: ranking interaction: %v", err)
+		}
+	}
+}
+
+func (b *Bot) handleSetupComponent(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.MessageComponentInteractionData) error {
+	var reply string
+	switch data.CustomID {
+	case "setup:locale":
+		if len(data.Values) == 0 {
+			return nil
+		}
+		if err := b.store.SetGuildSetting(i.GuildID, storage.SettingLocale, data.Values[0]); err != nil {
+			return err
+		}
+		reply = fmt.Sprintf("表示言語を `%s` に設定しました。", data.Values[0])
+	case "setup:channel":
+		if err := b.store.SetAnnounceChannel(i.GuildID, i.ChannelID); err != nil {
+			return err
+		}
+		reply = "このチャンネルを定期投稿先に設定しました。"
+	default:
+		return nil
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: reply,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// opsStaleAfter is how long an active player can go without a snapshot
+// before the digest counts them as stale. Refreshes run hourly, so two
+// hours of silence means errors or a saturated rate limit.
+const opsStaleAfter = 2 * time.Hour
+
+// cmdOps is the operator's cross-guild health digest: fleet size,
+// storage growth, refresh staleness, task failures and the busiest
+// guilds over the last 24 hours — everything the database records from
+// both processes, so the worker's activity shows up too.
+func cmdOps(ctx *Context) error {
+	if len(ctx.Args) == 0 || strings.ToLower(ctx.Args[0]) != "digest" {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "ops digest`")
+	}
+	now := time.Now()
+	stats, err := ctx.Bot.store.OpsStats(now.Add(-24*time.Hour), now.Add(-opsStaleAfter))
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("**運用ダイジェスト** (直近24時間)\n")
+	fmt.Fprintf(&b, "📊 規模: %dサーバー ／ %dプレイヤー\n", stats.Guilds, stats.Players)
+	fmt.Fprintf(&b, "💾 ストレージ: スナップショット %d件 (+%d) ／ 試合キャッシュ %d件 (+%d)\n",
+		stats.Snapshots, stats.SnapshotsNew, stats.Matches, stats.MatchesNew)
+	refresh := "問題なし"
+	if stats.StalePlayers > 0 || stats.MissingPlayers > 0 {
+		refresh = fmt.Sprintf("%d時間以上未更新 %d人 ／ 404検出 %d人",
+			int(opsStaleAfter.Hours()), stats.StalePlayers, stats.MissingPlayers)
+	}
+	b.WriteString("🔄 更新: " + refresh + "\n")
+	task := fmt.Sprintf("保留 %d件", stats.PendingTasks)
+	if len(stats.FailedTasks) > 0 {
+		kinds := make([]string, 0, len(stats.FailedTasks))
+		for kind := range stats.FailedTasks {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		parts := make([]string, 0, len(kinds))
+		for _, kind := range kinds {
+			parts = append(parts, fmt.Sprintf("%s×%d", kind, stats.FailedTasks[kind]))
+		}
+		task += " ／ ⚠ 失敗 " + strings.Join(parts, ", ")
+	}
+	b.WriteString("📋 タスク: " + task + "\n")
+	if len(stats.Busiest) > 0 {
+		parts := make([]string, 0, len(stats.Busiest))
+		for _, a := range stats.Busiest {
+			parts = append(parts, fmt.Sprintf("%s (%d試合)", a.GuildID, a.Matches))
+		}
+		b.WriteString("🏟 アクティブなサーバー: " + strings.Join(parts, " ／ "))
+	}
+	return ctx.Reply(strings.TrimRight(b.String(), "\n"))
+}
@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdPause marks a player inactive: the worker stops refreshing them
+// and the ranking greys them out at the bottom until unpaused.
+func cmdPause(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "pause <ゲーム名#タグ> [YYYYMMDD]`")
+	}
+	until := time.Time{}
+	args := ctx.Args
+	if len(args) >= 2 {
+		if parsed, err := time.ParseInLocation("20060102", args[len(args)-1], render.JST); err == nil {
+			// Pause through the end of the given JST day.
+			until = parsed.AddDate(0, 0, 1)
+			args = args[:len(args)-1]
+		}
+	}
+	player, err := pauseTarget(ctx, args)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Bot.store.SetPlayerPaused(player.ID, true, until); err != nil {
+		return err
+	}
+	if until.IsZero() {
+		return ctx.Reply(fmt.Sprintf("**%s** を休止扱いにしました。`%sunpause` で再開できます。",
+			player.RiotID(), ctx.Bot.cfg.Prefix))
+	}
+	return ctx.Reply(fmt.Sprintf("**%s** を %s まで休止扱いにしました。",
+		player.RiotID(), until.AddDate(0, 0, -1).Format("2006/01/02")))
+}
+
+// cmdUnpause puts a paused player back into the refresh cycle.
+func cmdUnpause(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "unpause <ゲーム名#タグ>`")
+	}
+	player, err := pauseTarget(ctx, ctx.Args)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Bot.store.SetPlayerPaused(player.ID, false, time.Time{}); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("**%s** の休止を解除しました。", player.RiotID()))
+}
+
+// pauseTarget resolves the registered player named by the arguments.
+func pauseTarget(ctx *Context, args []string) (*storage.Player, error) {
+	gameName, tagLine, ok := parseRiotID(strings.Join(args, " "))
+	if !ok {
+		return nil, errUsage("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if err == storage.ErrNotRegistered {
+		return nil, errUsage("そのプレイヤーは登録されていません。")
+	}
+	return player, err
+}
@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// cmdWeekstats and cmdMonthstats aggregate the roster's cached games
+// and LP movement over a rolling window; they share the daystats
+// machinery with a larger span.
+func cmdWeekstats(ctx *Context) error  { return periodStatsReply(ctx, 7, "直近7日間") }
+func cmdMonthstats(ctx *Context) error { return periodStatsReply(ctx, 30, "直近30日間") }
+
+// periodStatsReply renders wins, losses, winrate and net LP change per
+// player over the last days days, most games first. An optional Riot
+// ID argument narrows the table to that player.
+func periodStatsReply(ctx *Context, days int, label string) error {
+	var filterName, filterTag string
+	if len(ctx.Args) > 0 {
+		var ok bool
+		filterName, filterTag, ok = parseRiotID(strings.Join(ctx.Args, " "))
+		if !ok {
+			return ctx.Reply("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+		}
+	}
+
+	now := time.Now().In(render.JST)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, render.JST).AddDate(0, 0, -(days - 1))
+	recap, err := ctx.Bot.store.RecapData(ctx.GuildID(), from, now)
+	if err != nil {
+		return err
+	}
+	players, err := ctx.Bot.store.LatestRanks(ctx.GuildID(), riotapi.QueueSolo)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Bot.store.AttachLPDeltas(players, riotapi.QueueSolo, from); err != nil {
+		return err
+	}
+	deltas := map[int64]string{}
+	for i := range players {
+		if players[i].LPDeltaKnown {
+			deltas[players[i].ID] = fmt.Sprintf("%+dLP", players[i].LPDelta)
+		}
+	}
+
+	type row struct {
+		name        string
+		wins, games int
+		delta       string
+	}
+	var rows []row
+	for _, pm := range recap {
+		if filterName != "" && !(strings.EqualFold(pm.GameName, filterName) && strings.EqualFold(pm.TagLine, filterTag)) {
+			continue
+		}
+		if len(pm.Matches) == 0 && deltas[pm.ID] == "" {
+			continue
+		}
+		wins := 0
+		for _, m := range pm.Matches {
+			for _, part := range m.Info.Participants {
+				if part.PUUID == pm.PUUID && part.Win {
+					wins++
+				}
+			}
+		}
+		rows = append(rows, row{pm.RiotID(), wins, len(pm.Matches), deltas[pm.ID]})
+	}
+	if len(rows) == 0 {
+		return ctx.Reply(label + "の試合データがありません。")
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].games != rows[j].games {
+			return rows[i].games > rows[j].games
+		}
+		return rows[i].wins > rows[j].wins
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%sの戦績** (%s〜%s)\n```\n", label, from.Format("01/02"), now.Format("01/02"))
+	for i, r := range rows {
+		line := fmt.Sprintf("%2d. %-24s %d勝%d敗", i+1, r.name, r.wins, r.games-r.wins)
+		if r.games > 0 {
+			line += fmt.Sprintf(" (勝率%.0f%%)", float64(r.wins)/float64(r.games)*100)
+		}
+		if r.delta != "" {
+			line += " " + r.delta
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("```")
+	return ctx.Reply(b.String())
+}
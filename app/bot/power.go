@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// powerWeights is the admin-configurable formula behind !powerranking.
+// Score = Tier*tierPoints + Winrate*recentWinrate% + Games*recentGames.
+type powerWeights struct {
+	Tier    float64 `json:"tier"`
+	Winrate float64 `json:"winrate"`
+	Games   float64 `json:"games"`
+}
+
+// defaultPowerWeights emphasizes rank but rewards current form.
+var defaultPowerWeights = powerWeights{Tier: 1, Winrate: 0.5, Games: 0.2}
+
+func loadPowerWeights(ctx *Context) (powerWeights, error) {
+	raw, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingPowerWeights, "")
+	if err != nil || raw == "" {
+		return defaultPowerWeights, err
+	}
+	var w powerWeights
+	if err := json.Unmarshal([]byte(raw), &w); err != nil {
+		return defaultPowerWeights, nil // ignore corrupted settings
+	}
+	return w, nil
+}
+
+func cmdPowerRanking(ctx *Context) error {
+	if len(ctx.Args) >= 1 && ctx.Args[0] == "set" {
+		return setPowerWeights(ctx)
+	}
+
+	weights, err := loadPowerWeights(ctx)
+	if err != nil {
+		return err
+	}
+	players, err := ctx.Bot.store.LatestRanks(ctx.GuildID(), riotapi.QueueSolo)
+	if err != nil {
+		return err
+	}
+	type scored struct {
+		name  string
+		score float64
+	}
+	var rowsOut []scored
+	for i := range players {
+		p := &players[i]
+		if p.Tier == "" {
+			continue
+		}
+		wins, games, err := ctx.Bot.store.RecentSoloForm(&p.Player, 20)
+		if err != nil {
+			return err
+		}
+		winrate := 0.0
+		if games > 0 {
+			winrate = float64(wins) / float64(games) * 100
+		}
+		// Tier points are the comparable rank value scaled to "divisions".
+		tierPoints := float64(rank.Value(p.Tier, p.Division, p.LP)) / 100
+		score := weights.Tier*tierPoints + weights.Winrate*winrate + weights.Games*float64(games)
+		rowsOut = append(rowsOut, scored{name: p.RiotID(), score: score})
+	}
+	if len(rowsOut) == 0 {
+		return ctx.Reply("ランク情報のあるプレイヤーがいません。")
+	}
+	sort.SliceStable(rowsOut, func(i, j int) bool { return rowsOut[i].score > rowsOut[j].score })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**パワーランキング** (tier×%.1f + winrate×%.1f + games×%.1f)\n```\n",
+		weights.Tier, weights.Winrate, weights.Games)
+	for i, r := range rowsOut {
+		fmt.Fprintf(&b, "%2d. %-24s %6.1f pt\n", i+1, r.name, r.score)
+	}
+	b.WriteString("```")
+	return ctx.Reply(b.String())
+}
+
+// setPowerWeights stores a new weight set (admin only).
+func setPowerWeights(ctx *Context) error {
+	admin, err := ctx.isAdmin()
+	if err != nil {
+		return err
+	}
+	if !admin {
+		return ctx.Reply("重みの変更はサーバー管理者のみ行えます。")
+	}
+	if len(ctx.Args) != 4 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "powerranking set <tier> <winrate> <games>` 例: `" +
+			ctx.Bot.cfg.Prefix + "powerranking set 1 0.5 0.2`")
+	}
+	var w powerWeights
+	var errs []string
+	for i, dst := range []*float64{&w.Tier, &w.Winrate, &w.Games} {
+		v, err := strconv.ParseFloat(ctx.Args[i+1], 64)
+		if err != nil {
+			errs = append(errs, ctx.Args[i+1])
+			continue
+		}
+		*dst = v
+	}
+	if len(errs) > 0 {
+		return ctx.Reply("重みは数値で指定してください: " + strings.Join(errs, ", "))
+	}
+	blob, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingPowerWeights, string(blob)); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("パワーランキングの重みを tier=%.1f winrate=%.1f games=%.1f に設定しました。",
+		w.Tier, w.Winrate, w.Games))
+}
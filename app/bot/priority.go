@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cmdPriority marks a player for the worker's frequent-refresh pass
+// (every 10 minutes instead of the hourly guild slot) — useful when
+// someone is on a promotion push the whole group is watching.
+func cmdPriority(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		players, err := ctx.Bot.store.Players(ctx.GuildID())
+		if err != nil {
+			return err
+		}
+		var names []string
+		for i := range players {
+			if players[i].Priority {
+				names = append(names, players[i].RiotID())
+			}
+		}
+		if len(names) == 0 {
+			return ctx.Reply("優先更新中のプレイヤーはいません。`" + ctx.Bot.cfg.Prefix + "priority <ゲーム名#タグ> on` で設定できます。")
+		}
+		return ctx.Reply("**優先更新中**: " + strings.Join(names, ", "))
+	}
+	args := ctx.Args
+	priority := true
+	switch strings.ToLower(args[len(args)-1]) {
+	case "on":
+		args = args[:len(args)-1]
+	case "off":
+		priority = false
+		args = args[:len(args)-1]
+	}
+	player, err := pauseTarget(ctx, args)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Bot.store.SetPlayerPriority(player.ID, priority); err != nil {
+		return err
+	}
+	if priority {
+		return ctx.Reply(fmt.Sprintf("**%s** を優先更新にしました。約10分ごとに更新されます。", player.RiotID()))
+	}
+	return ctx.Reply(fmt.Sprintf("**%s** の優先更新を解除しました。通常の更新周期に戻ります。", player.RiotID()))
+}
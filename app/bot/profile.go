@@ -0,0 +1,312 @@
+package bot
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// Profile-card windows: recent record, champion pool sample, and how far
+// back the peak-rank search reaches.
+const (
+	profileRecordDays = 7
+	profileChampDays  = 30
+	profilePeakDays   = 365
+	profileTopChamps  = 3
+)
+
+// cmdProfile posts a rich profile card for a registered player: current
+// ranks, peak rank, champion pool, recent record and the linked Discord
+// user, with buttons jumping to the history and activity views.
+func cmdProfile(ctx *Context) error {
+	p, err := profileTarget(ctx)
+	if err == storage.ErrNotRegistered {
+		return ctx.Reply("そのプレイヤーは登録されていません。`" + ctx.Bot.cfg.Prefix + "add` で登録してください。")
+	}
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "profile <ゲーム名#タグ|@ユーザー>`" +
+			" (引数なしで自分のリンク済みアカウント)")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  p.RiotID(),
+		Footer: &discordgo.MessageEmbedFooter{Text: p.Platform + " ／ TFTランクは未対応"},
+	}
+	for _, q := range []struct{ queue, label string }{
+		{riotapi.QueueSolo, "ソロランク"},
+		{riotapi.QueueFlex, "フレックス"},
+	} {
+		snap, err := ctx.Bot.store.LatestSnapshot(p.ID, q.queue)
+		if err != nil {
+			return err
+		}
+		display := "ランクなし"
+		if snap != nil && snap.Tier != "" {
+			display = rank.Format(snap.Tier, snap.Division, snap.LP)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: q.label, Value: display, Inline: true,
+		})
+	}
+
+	peak, err := profilePeak(ctx.Bot.store, p)
+	if err != nil {
+		return err
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "自己ベスト (1年)", Value: peak, Inline: true,
+	})
+
+	to := time.Now()
+	st, err := statsForPlayer(ctx.Bot.store, p, to.AddDate(0, 0, -profileRecordDays), to)
+	if err != nil {
+		return err
+	}
+	record := "試合なし"
+	if st.games > 0 {
+		record = fmt.Sprintf("%d勝%d敗 (KDA %.1f)", st.wins, st.games-st.wins, st.kda())
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: fmt.Sprintf("直近%d日", profileRecordDays), Value: record, Inline: true,
+	})
+
+	champs, err := profileChampions(ctx.Bot.store, p)
+	if err != nil {
+		return err
+	}
+	if champs != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("得意チャンピオン (直近%d日)", profileChampDays), Value: champs,
+		})
+	}
+	linked := "未リンク"
+	if p.DiscordUserID != "" {
+		linked = "<@" + p.DiscordUserID + ">"
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "Discord", Value: linked, Inline: true,
+	})
+
+	_, err = ctx.Session.ChannelMessageSendComplex(ctx.Message.ChannelID, &discordgo.MessageSend{
+		Embed: embed,
+		Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				CustomID: "profile:history:" + p.RiotID(),
+				Label:    "📈 ランク履歴",
+				Style:    discordgo.SecondaryButton,
+			},
+			discordgo.Button{
+				CustomID: "profile:activity:" + p.RiotID(),
+				Label:    "📊 活動グラフ",
+				Style:    discordgo.SecondaryButton,
+			},
+		}}},
+	})
+	return err
+}
+
+// profileTarget resolves the card's subject: an explicit Riot ID, a
+// mentioned user's linked account, or the caller's own. Nil without an
+// error means the usage line should be shown.
+func profileTarget(ctx *Context) (*storage.Player, error) {
+	if len(ctx.Message.Mentions) > 0 {
+		return bestLinkedAccount(ctx, ctx.Message.Mentions[0].ID)
+	}
+	if len(ctx.Args) > 0 {
+		gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+		if !ok {
+			return nil, nil
+		}
+		return ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	}
+	return bestLinkedAccount(ctx, ctx.Message.Author.ID)
+}
+
+// bestLinkedAccount picks the user's strongest linked account by latest
+// solo snapshot, mirroring how leaderboards represent linked users.
+func bestLinkedAccount(ctx *Context, userID string) (*storage.Player, error) {
+	accounts, err := ctx.Bot.store.PlayersByOwner(ctx.GuildID(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, storage.ErrNotRegistered
+	}
+	bestIdx, bestValue := 0, -1
+	for i := range accounts {
+		snap, err := ctx.Bot.store.LatestSnapshot(accounts[i].ID, riotapi.QueueSolo)
+		if err != nil {
+			return nil, err
+		}
+		if snap == nil || snap.Tier == "" {
+			continue
+		}
+		if v := rank.Value(snap.Tier, snap.Division, snap.LP); v > bestValue {
+			bestValue, bestIdx = v, i
+		}
+	}
+	return &accounts[bestIdx], nil
+}
+
+// profilePeak scans a year of daily snapshots for the highest solo rank
+// on record.
+func profilePeak(store *storage.Store, p *storage.Player) (string, error) {
+	snaps, err := store.DailySnapshots(p.ID, riotapi.QueueSolo, time.Now().AddDate(0, 0, -profilePeakDays))
+	if err != nil {
+		return "", err
+	}
+	best, bestValue := "記録なし", -1
+	for _, snap := range snaps {
+		if snap.Tier == "" {
+			continue
+		}
+		if v := rank.Value(snap.Tier, snap.Division, snap.LP); v > bestValue {
+			bestValue = v
+			best = fmt.Sprintf("%s (%s)", rank.Format(snap.Tier, snap.Division, snap.LP),
+				snap.TakenAt.In(render.JST).Format("2006/01/02"))
+		}
+	}
+	return best, nil
+}
+
+// profileChampions summarizes the player's most-picked champions from
+// cached solo-queue games.
+func profileChampions(store *storage.Store, p *storage.Player) (string, error) {
+	to := time.Now()
+	matches, err := store.MatchesForPlayer(p.ID, to.AddDate(0, 0, -profileChampDays), to)
+	if err != nil {
+		return "", err
+	}
+	counts := map[string]int{}
+	wins := map[string]int{}
+	for _, m := range matches {
+		if m.Info.QueueID != riotapi.QueueIDSolo {
+			continue
+		}
+		for i := range m.Info.Participants {
+			part := &m.Info.Participants[i]
+			if part.PUUID != p.PUUID {
+				continue
+			}
+			counts[part.ChampionName]++
+			if part.Win {
+				wins[part.ChampionName]++
+			}
+			break
+		}
+	}
+	if len(counts) == 0 {
+		return "", nil
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > profileTopChamps {
+		names = names[:profileTopChamps]
+	}
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %d勝%d敗", name, wins[name], counts[name]-wins[name]))
+	}
+	return strings.Join(parts, " ／ "), nil
+}
+
+// handleProfileComponent answers the profile-card buttons with the
+// requested view, ephemerally so the card's channel stays clean.
+func (b *Bot) handleProfileComponent(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.MessageComponentInteractionData) error {
+	rest := strings.TrimPrefix(data.CustomID, "profile:")
+	action, id, ok := strings.Cut(rest, ":")
+	if !ok {
+		return nil
+	}
+	gameName, tagLine, ok := parseRiotID(id)
+	if !ok {
+		return nil
+	}
+	p, err := b.store.PlayerByRiotID(i.GuildID, gameName, tagLine)
+	if err != nil {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: userMessage(err),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	resp := &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral}
+	switch action {
+	case "history":
+		text, err := rankHistoryText(b.store, p, riotapi.QueueSolo, "ソロ")
+		if err != nil {
+			return err
+		}
+		resp.Content = text
+	case "activity":
+		img, total, err := playerActivityGraph(b.store, p)
+		if err != nil {
+			return err
+		}
+		if img == nil {
+			resp.Content = fmt.Sprintf("**%s** のこの期間のランク戦の記録がまだありません。", p.RiotID())
+			break
+		}
+		resp.Content = fmt.Sprintf("**%s の活動** (直近%d日): 合計 %d試合", p.RiotID(), groupActivityDays, total)
+		resp.Files = []*discordgo.File{{
+			Name:        "activity.png",
+			ContentType: "image/png",
+			Reader:      bytes.NewReader(img),
+		}}
+	default:
+		return nil
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: resp,
+	})
+}
+
+// playerActivityGraph renders one player's ranked games per day as the
+// same contribution graph !groupactivity uses. Nil image means no games.
+func playerActivityGraph(store *storage.Store, p *storage.Player) ([]byte, int, error) {
+	to := time.Now().In(render.JST)
+	from := to.AddDate(0, 0, -(groupActivityDays - 1))
+	matches, err := store.MatchesForPlayer(p.ID, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+	perDay := map[string]int{}
+	total := 0
+	for _, m := range matches {
+		if m.Info.QueueID != riotapi.QueueIDSolo {
+			continue
+		}
+		end := time.UnixMilli(m.Info.GameEndTimestamp).In(render.JST)
+		perDay[end.Format("2006-01-02")]++
+		total++
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+	img, err := render.ActivityGraph(perDay, from, to)
+	return img, total, err
+}
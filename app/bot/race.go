@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// raceReach is how far below the target (in rank value points) a player
+// can be and still count as "in the race" — one full tier.
+const raceReach = 400
+
+func cmdRace(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "race <ティア> [ディビジョン]` 例: `" +
+			ctx.Bot.cfg.Prefix + "race diamond 4`")
+	}
+	tier, ok := rank.NormalizeTier(ctx.Args[0])
+	if !ok {
+		return ctx.Reply("ティアは iron〜challenger で指定してください。")
+	}
+	division := "IV"
+	if len(ctx.Args) >= 2 {
+		if division, ok = rank.NormalizeDivision(ctx.Args[1]); !ok {
+			return ctx.Reply("ディビジョンは 1〜4 (I〜IV) で指定してください。")
+		}
+	}
+	target := rank.Value(tier, division, 0)
+
+	players, err := ctx.Bot.store.LatestRanks(ctx.GuildID(), riotapi.QueueSolo)
+	if err != nil {
+		return err
+	}
+	type racer struct {
+		name    string
+		display string
+		value   int
+	}
+	var racers []racer
+	for _, p := range players {
+		v := rank.Value(p.Tier, p.Division, p.LP)
+		if v < target-raceReach || v >= target+raceReach {
+			continue
+		}
+		racers = append(racers, racer{
+			name:    p.RiotID(),
+			display: rank.Format(p.Tier, p.Division, p.LP),
+			value:   v,
+		})
+	}
+	if len(racers) == 0 {
+		return ctx.Reply(fmt.Sprintf("%s %s 圏内のプレイヤーはいません。", tier, division))
+	}
+	sort.SliceStable(racers, func(i, j int) bool { return racers[i].value > racers[j].value })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s %s へのレース**\n```\n", tier, division)
+	for i, r := range racers {
+		if r.value >= target {
+			fmt.Fprintf(&b, " ✓ %-24s %-18s 達成！\n", r.name, r.display)
+			continue
+		}
+		fmt.Fprintf(&b, "%2d. %-24s %-18s あと%dLP相当\n", i+1, r.name, r.display, target-r.value)
+	}
+	b.WriteString("```")
+	return ctx.Reply(b.String())
+}
@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdRaceday manages the guild's LP race days. On a race day the worker
+// opens a thread at the daily reset, posts live LP updates for the
+// roster as refreshes land, and closes the thread with final standings
+// at the next reset.
+func cmdRaceday(ctx *Context) error {
+	usage := "使い方: `" + ctx.Bot.cfg.Prefix + "raceday add <YYYYMMDD>|remove <YYYYMMDD>|list`"
+	days, err := racedayList(ctx)
+	if err != nil {
+		return err
+	}
+	action := "list"
+	if len(ctx.Args) > 0 {
+		action = strings.ToLower(ctx.Args[0])
+	}
+	switch action {
+	case "list":
+		if len(days) == 0 {
+			return ctx.Reply("レースデーは設定されていません。`" + ctx.Bot.cfg.Prefix + "raceday add <YYYYMMDD>` で追加できます。")
+		}
+		return ctx.Reply("**レースデー**: " + strings.Join(days, ", "))
+	case "add":
+		if len(ctx.Args) < 2 {
+			return ctx.Reply(usage)
+		}
+		day, err := time.ParseInLocation("20060102", ctx.Args[1], render.JST)
+		if err != nil {
+			return ctx.Reply("日付は `YYYYMMDD` の形式で指定してください。")
+		}
+		today := time.Now().In(render.JST)
+		if day.Before(time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, render.JST)) {
+			return ctx.Reply("過去の日付は指定できません。")
+		}
+		formatted := day.Format("20060102")
+		for _, d := range days {
+			if d == formatted {
+				return ctx.Reply(fmt.Sprintf("%s は既にレースデーです。", formatted))
+			}
+		}
+		days = append(days, formatted)
+		sort.Strings(days)
+		if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingRaceDays, strings.Join(days, ",")); err != nil {
+			return err
+		}
+		return ctx.Reply(fmt.Sprintf("🏁 %s をレースデーにしました。当日の朝5時にスレッドが開きます。", formatted))
+	case "remove":
+		if len(ctx.Args) < 2 {
+			return ctx.Reply(usage)
+		}
+		kept := days[:0]
+		for _, d := range days {
+			if d != ctx.Args[1] {
+				kept = append(kept, d)
+			}
+		}
+		if len(kept) == len(days) {
+			return ctx.Reply(fmt.Sprintf("%s はレースデーではありません。", ctx.Args[1]))
+		}
+		if err := ctx.Bot.store.SetGuildSetting(ctx.GuildID(), storage.SettingRaceDays, strings.Join(kept, ",")); err != nil {
+			return err
+		}
+		return ctx.Reply(fmt.Sprintf("%s をレースデーから外しました。", ctx.Args[1]))
+	default:
+		return ctx.Reply(usage)
+	}
+}
+
+// racedayList reads the guild's configured race days.
+func racedayList(ctx *Context) ([]string, error) {
+	stored, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingRaceDays, "")
+	if err != nil || stored == "" {
+		return nil, err
+	}
+	return strings.Split(stored, ","), nil
+}
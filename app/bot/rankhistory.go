@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// rankHistoryDays is how far back !rankhistory reaches.
+const rankHistoryDays = 30
+
+// queueByName maps the user-facing queue argument to the league-v4
+// queueType. Snapshots are stored per queue, so flex history is as
+// complete as solo.
+var queueByName = map[string]string{
+	"solo": riotapi.QueueSolo,
+	"flex": riotapi.QueueFlex,
+}
+
+// cmdRankHistory lists a player's end-of-day ranks, one line per day
+// the rank was observed, for the chosen queue.
+func cmdRankHistory(ctx *Context) error {
+	args := ctx.Args
+	queue := riotapi.QueueSolo
+	queueLabel := "ソロ"
+	if len(args) > 0 {
+		if q, ok := queueByName[strings.ToLower(args[len(args)-1])]; ok {
+			queue = q
+			if q == riotapi.QueueFlex {
+				queueLabel = "フレックス"
+			}
+			args = args[:len(args)-1]
+		}
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(args, " "))
+	if !ok {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "rankhistory <ゲーム名#タグ> [solo|flex]`")
+	}
+	p, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if err != nil {
+		return err
+	}
+	text, err := rankHistoryText(ctx.Bot.store, p, queue, queueLabel)
+	if err != nil {
+		return err
+	}
+	return ctx.Reply(text)
+}
+
+// rankHistoryText renders the daily rank list for one player and queue;
+// it is shared by !rankhistory and the profile-card history button.
+func rankHistoryText(store *storage.Store, p *storage.Player, queue, queueLabel string) (string, error) {
+	from := time.Now().AddDate(0, 0, -rankHistoryDays)
+	snaps, err := store.DailySnapshots(p.ID, queue, from)
+	if err != nil {
+		return "", err
+	}
+	if len(snaps) == 0 {
+		return fmt.Sprintf("**%s** の%sランク履歴はまだありません。", p.RiotID(), queueLabel), nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s の%sランク履歴** (直近%d日)\n```\n", p.RiotID(), queueLabel, rankHistoryDays)
+	prevValue := -1
+	for _, snap := range snaps {
+		value := rank.Value(snap.Tier, snap.Division, snap.LP)
+		delta := ""
+		if prevValue >= 0 && value != prevValue {
+			delta = fmt.Sprintf(" (%+d)", value-prevValue)
+		}
+		fmt.Fprintf(&b, "%s  %-18s%s\n", snap.TakenAt.In(render.JST).Format("01/02"),
+			rank.Format(snap.Tier, snap.Division, snap.LP), delta)
+		prevValue = value
+	}
+	b.WriteString("```")
+	return b.String(), nil
+}
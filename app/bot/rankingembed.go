@@ -0,0 +1,247 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/i18n"
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// rankingPageSize is how many players one embed page shows; larger
+// rosters get ◀️ ▶️ buttons to browse the rest.
+const rankingPageSize = 20
+
+// tierEmoji decorates embed entries per tier.
+var tierEmoji = map[string]string{
+	"IRON":        "🪨",
+	"BRONZE":      "🥉",
+	"SILVER":      "🥈",
+	"GOLD":        "🥇",
+	"PLATINUM":    "💠",
+	"EMERALD":     "💚",
+	"DIAMOND":     "💎",
+	"MASTER":      "🟣",
+	"GRANDMASTER": "🔴",
+	"CHALLENGER":  "👑",
+}
+
+// opggRegion maps a platform routing value to OP.GG's region slug
+// (jp1 → jp, euw1 → euw, kr → kr).
+func opggRegion(platform string) string {
+	return strings.TrimRight(platform, "0123456789")
+}
+
+// opggURL is the player's OP.GG profile link.
+func opggURL(p *storage.Player) string {
+	return fmt.Sprintf("https://op.gg/summoners/%s/%s",
+		opggRegion(p.Platform), url.PathEscape(p.GameName+"-"+p.TagLine))
+}
+
+// rankingEmbed renders one page of the leaderboard as a rich embed:
+// one field per player with tier emoji, LP, winrate and an OP.GG link.
+// Ordering matches render.Ranking (paused players sink to the bottom).
+// page is clamped; the page count is returned alongside.
+func rankingEmbed(players []storage.RankedPlayer, title string, loc i18n.Locale, page int) (*discordgo.MessageEmbed, int) {
+	now := time.Now()
+	sorted := make([]storage.RankedPlayer, len(players))
+	copy(sorted, players)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, pj := sorted[i].IsPaused(now), sorted[j].IsPaused(now)
+		if pi != pj {
+			return !pi
+		}
+		return rank.Value(sorted[i].Tier, sorted[i].Division, sorted[i].LP) >
+			rank.Value(sorted[j].Tier, sorted[j].Division, sorted[j].LP)
+	})
+
+	var fields []*discordgo.MessageEmbedField
+	var newest time.Time
+	pos := 0
+	for i := range sorted {
+		p := &sorted[i]
+		name := p.RiotID()
+		if p.IsPaused(now) {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:  "💤 " + name,
+				Value: fmt.Sprintf("%s (休止中) ／ [OP.GG](%s)", rank.Format(p.Tier, p.Division, p.LP), opggURL(&p.Player)),
+			})
+			continue
+		}
+		pos++
+		emoji := tierEmoji[p.Tier]
+		if emoji == "" {
+			emoji = "❔"
+		}
+		value := rank.Format(p.Tier, p.Division, p.LP)
+		if games := p.Wins + p.Losses; games > 0 {
+			value += fmt.Sprintf(" ／ 勝率%.0f%% (%s)",
+				float64(p.Wins)/float64(games)*100, loc.WinLoss(p.Wins, p.Losses))
+		}
+		if p.LPDeltaKnown && p.LPDelta != 0 {
+			value += fmt.Sprintf(" ／ 今日%+dLP", p.LPDelta)
+		}
+		if p.Trend != "" {
+			value += " " + p.Trend
+		}
+		value += fmt.Sprintf(" ／ [OP.GG](%s)", opggURL(&p.Player))
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%d. %s %s", pos, name, emoji),
+			Value: render.ClampField(value),
+		})
+		if p.TakenAt.After(newest) {
+			newest = p.TakenAt
+		}
+	}
+
+	pages := (len(fields) + rankingPageSize - 1) / rankingPageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= pages {
+		page = pages - 1
+	}
+	start := page * rankingPageSize
+	end := min(start+rankingPageSize, len(fields))
+	embed := &discordgo.MessageEmbed{Title: "🏆 " + title, Fields: fields[start:end]}
+	var footer []string
+	if !newest.IsZero() {
+		footer = append(footer, loc.AsOf(newest.In(render.JST)))
+	}
+	if pages > 1 {
+		footer = append(footer, fmt.Sprintf("%d/%dページ", page+1, pages))
+	}
+	if len(footer) > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: strings.Join(footer, " ／ ")}
+	}
+	return embed, pages
+}
+
+// rankingPageState is a paginated ranking message's state, persisted
+// per message so the buttons survive gateway restarts.
+type rankingPageState struct {
+	Queue string `json:"queue"`
+	Page  int    `json:"page"`
+}
+
+func rankingPageButtons() []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+		discordgo.Button{CustomID: "ranking:prev", Label: "◀️", Style: discordgo.SecondaryButton},
+		discordgo.Button{CustomID: "ranking:next", Label: "▶️", Style: discordgo.SecondaryButton},
+	}}}
+}
+
+// sendPagedRanking posts page one of a large leaderboard with browse
+// buttons, degrading to the (chunked) text rendering when the send
+// fails.
+func sendPagedRanking(ctx *Context, embed *discordgo.MessageEmbed, queue, fallback string) error {
+	sent, err := ctx.Session.ChannelMessageSendComplex(ctx.Message.ChannelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: rankingPageButtons(),
+	})
+	if err != nil {
+		return ctx.Reply(fallback)
+	}
+	payload, err := json.Marshal(&rankingPageState{Queue: queue})
+	if err != nil {
+		return err
+	}
+	return ctx.Bot.store.SaveInteractionState(sent.ID, ctx.GuildID(), "ranking", string(payload))
+}
+
+// handleRankingComponent flips a paginated ranking message to the
+// previous or next page, re-reading the latest snapshots so the page
+// shown is always current.
+func (b *Bot) handleRankingComponent(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.MessageComponentInteractionData) error {
+	state, err := b.store.InteractionState(i.Message.ID)
+	if err != nil {
+		return err
+	}
+	if state == nil || state.Kind != "ranking" {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "このランキングは期限切れです。もう一度コマンドを実行してください。",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+	var ps rankingPageState
+	if err := json.Unmarshal([]byte(state.Payload), &ps); err != nil {
+		return err
+	}
+	if data.CustomID == "ranking:next" {
+		ps.Page++
+	} else {
+		ps.Page--
+	}
+	players, err := rankingData(b.store, i.GuildID, ps.Queue)
+	if err != nil {
+		return err
+	}
+	stored, err := b.store.GuildSetting(i.GuildID, storage.SettingLocale, "ja")
+	if err != nil {
+		return err
+	}
+	title := render.SoloRankingTitle
+	if ps.Queue == riotapi.QueueFlex {
+		title = render.FlexRankingTitle
+	}
+	embed, pages := rankingEmbed(players, title, i18n.Parse(stored), ps.Page)
+	if ps.Page < 0 {
+		ps.Page = 0
+	}
+	if ps.Page >= pages {
+		ps.Page = pages - 1
+	}
+	payload, err := json.Marshal(&ps)
+	if err != nil {
+		return err
+	}
+	if err := b.store.SaveInteractionState(i.Message.ID, i.GuildID, "ranking", string(payload)); err != nil {
+		return err
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: rankingPageButtons(),
+		},
+	})
+}
+
+// rankingData loads the leaderboard rows the ranking views share: the
+// latest snapshots per queue, reduced to each member's best account,
+// with trend arrows attached for solo.
+func rankingData(store *storage.Store, guildID, queue string) ([]storage.RankedPlayer, error) {
+	players, err := store.LatestRanks(guildID, queue)
+	if err != nil {
+		return nil, err
+	}
+	players = bestPerOwner(players)
+	if queue == riotapi.QueueSolo {
+		// Trend arrows come from recent solo form, so they would
+		// mislead next to flex standings.
+		if err := store.AttachTrends(players); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now().In(render.JST)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, render.JST)
+	if err := store.AttachLPDeltas(players, queue, dayStart); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
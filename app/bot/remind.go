@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/sched"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdRemind manages recurring DM reminders, delivered by the worker on
+// the same minutely sweep as the guild schedules.
+func cmdRemind(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		return remindUsage(ctx)
+	}
+	switch ctx.Args[0] {
+	case "me":
+		return remindAdd(ctx, ctx.Args[1:])
+	case "list":
+		return remindList(ctx)
+	case "delete":
+		return remindDelete(ctx, ctx.Args[1:])
+	default:
+		return remindUsage(ctx)
+	}
+}
+
+func remindUsage(ctx *Context) error {
+	return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "remind me daily <HH:MM> <メッセージ>` / `" +
+		ctx.Bot.cfg.Prefix + "remind list` / `" + ctx.Bot.cfg.Prefix + "remind delete <番号>`")
+}
+
+// remindAdd handles `remind me daily 20:00 play 3 rankeds`.
+func remindAdd(ctx *Context, args []string) error {
+	if len(args) < 3 || args[0] != "daily" {
+		return remindUsage(ctx)
+	}
+	spec, ok := dailyCron(args[1])
+	if !ok {
+		return ctx.Reply("時刻は `HH:MM` の形式で指定してください。例: `20:00`")
+	}
+	message := strings.Trim(strings.Join(args[2:], " "), `"「」`)
+	if message == "" {
+		return remindUsage(ctx)
+	}
+	r := &storage.Reminder{
+		GuildID: ctx.GuildID(),
+		UserID:  ctx.Message.Author.ID,
+		Cron:    spec,
+		Message: message,
+	}
+	if err := ctx.Bot.store.AddReminder(r); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("リマインダー #%d を設定しました。毎日 %s にDMでお知らせします。", r.ID, args[1]))
+}
+
+func remindList(ctx *Context) error {
+	reminders, err := ctx.Bot.store.RemindersForUser(ctx.GuildID(), ctx.Message.Author.ID)
+	if err != nil {
+		return err
+	}
+	if len(reminders) == 0 {
+		return ctx.Reply("設定済みのリマインダーはありません。")
+	}
+	var b strings.Builder
+	b.WriteString("**リマインダー一覧**\n")
+	for _, r := range reminders {
+		fmt.Fprintf(&b, "・#%d `%s` %s\n", r.ID, r.Cron, r.Message)
+	}
+	return ctx.Reply(strings.TrimRight(b.String(), "\n"))
+}
+
+func remindDelete(ctx *Context, args []string) error {
+	if len(args) < 1 {
+		return remindUsage(ctx)
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(args[0], "#"), 10, 64)
+	if err != nil {
+		return ctx.Reply("削除するリマインダーの番号を指定してください。")
+	}
+	deleted, err := ctx.Bot.store.DeleteReminder(id, ctx.Message.Author.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ctx.Reply(fmt.Sprintf("リマインダー #%d は見つかりませんでした。", id))
+	}
+	return ctx.Reply(fmt.Sprintf("リマインダー #%d を削除しました。", id))
+}
+
+// dailyCron converts "HH:MM" into a daily cron expression.
+func dailyCron(hhmm string) (string, bool) {
+	parts := strings.SplitN(hhmm, ":", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+	hour, err1 := strconv.Atoi(parts[0])
+	minute, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return "", false
+	}
+	spec := fmt.Sprintf("%d %d * * *", minute, hour)
+	if err := sched.Validate(spec); err != nil {
+		return "", false
+	}
+	return spec, true
+}
@@ -0,0 +1,31 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdRemove deletes a player from the roster, along with their stored
+// snapshots and match links.
+func cmdRemove(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "remove <ゲーム名#タグ>`")
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return ctx.Reply("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if err == storage.ErrNotRegistered {
+		return ctx.Reply(fmt.Sprintf("**%s#%s** は登録されていません。", gameName, tagLine))
+	}
+	if err != nil {
+		return err
+	}
+	if err := ctx.Bot.store.DeletePlayer(player.ID); err != nil {
+		return err
+	}
+	return ctx.Reply(fmt.Sprintf("**%s** をランキングから削除しました。", player.RiotID()))
+}
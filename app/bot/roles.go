@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// roleSampleSize is how many recent matches decide a player's main role.
+const roleSampleSize = 20
+
+// roleAliases maps what users type to Riot's TeamPosition values.
+var roleAliases = map[string]string{
+	"top":     "TOP",
+	"jungle":  "JUNGLE",
+	"jg":      "JUNGLE",
+	"mid":     "MIDDLE",
+	"middle":  "MIDDLE",
+	"bot":     "BOTTOM",
+	"adc":     "BOTTOM",
+	"bottom":  "BOTTOM",
+	"sup":     "UTILITY",
+	"support": "UTILITY",
+	"utility": "UTILITY",
+}
+
+// roleLabels are the display names for TeamPosition values.
+var roleLabels = map[string]string{
+	"TOP":     "トップ",
+	"JUNGLE":  "ジャングル",
+	"MIDDLE":  "ミッド",
+	"BOTTOM":  "ボット",
+	"UTILITY": "サポート",
+}
+
+// normalizeRole resolves a user-typed role name to a TeamPosition
+// value, or "" when unknown.
+func normalizeRole(s string) string {
+	return roleAliases[strings.ToLower(s)]
+}
+
+// mainRole determines a player's most-played position from their
+// recent cached matches, or "" when there is not enough data.
+func mainRole(store *storage.Store, p *storage.Player) (string, error) {
+	matches, err := store.RecentMatchesForPlayer(p.ID, roleSampleSize)
+	if err != nil {
+		return "", err
+	}
+	counts := map[string]int{}
+	for _, m := range matches {
+		for _, part := range m.Info.Participants {
+			if part.PUUID == p.PUUID && part.TeamPosition != "" {
+				counts[part.TeamPosition]++
+				break
+			}
+		}
+	}
+	best, bestCount := "", 0
+	for role, n := range counts {
+		if n > bestCount {
+			best, bestCount = role, n
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,237 @@
+package bot
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// Command describes one chat command and how to run it.
+type Command struct {
+	Name  string
+	Usage string
+	Help  string
+	// Detail is the long-form description shown by "help <command>";
+	// when empty, Help is shown instead.
+	Detail string
+	// Examples are full invocations (without prefix) shown by
+	// "help <command>".
+	Examples []string
+	// AdminOnly restricts the command to members who can manage the
+	// guild (or the guild owner).
+	AdminOnly bool
+	// OperatorOnly restricts the command to the instance operators
+	// listed in OPERATOR_IDS; such commands also bypass maintenance
+	// mode so it can be turned off again.
+	OperatorOnly bool
+	// Feature names the feature flag gating this command; empty means
+	// always available.
+	Feature string
+	// Cacheable marks read-only commands whose reply depends only on
+	// stored data; their rendered output is memoized until the guild's
+	// data version changes.
+	Cacheable bool
+	// Budget overrides the default wall-clock budget. Handlers with long
+	// API loops check ctx.expired() and post partial results with a note
+	// instead of running unbounded.
+	Budget  time.Duration
+	Handler func(ctx *Context) error
+}
+
+// defaultCommandBudget bounds a command invocation's wall-clock time
+// unless the command declares its own budget.
+const defaultCommandBudget = 90 * time.Second
+
+// Context carries everything a command handler needs for one invocation.
+type Context struct {
+	Bot     *Bot
+	Session *discordgo.Session
+	Message *discordgo.MessageCreate
+	// Args are the whitespace-separated tokens after the command name.
+	Args []string
+	// onReply, when set, observes every reply this invocation sends;
+	// the dispatcher uses it to capture cacheable output.
+	onReply func(content string)
+	// send, when set, overrides where Reply delivers; slash-command
+	// invocations answer through the interaction instead of the channel.
+	send func(content string) error
+	// deadline is when this invocation's wall-clock budget runs out.
+	deadline time.Time
+}
+
+// expired reports whether the invocation's budget is spent. Handlers
+// with long per-item API loops check it between items and report what
+// they have instead of keeping the channel waiting.
+func (c *Context) expired() bool {
+	return !c.deadline.IsZero() && time.Now().After(c.deadline)
+}
+
+// GuildID is the guild the command was issued in.
+func (c *Context) GuildID() string { return c.Message.GuildID }
+
+// featureEnabled resolves a feature flag for this guild: a per-guild
+// `feature.<name>` setting wins, otherwise the operator-level FEATURES
+// state applies.
+func (c *Context) featureEnabled(name string) (bool, error) {
+	override, err := c.Bot.store.GuildSetting(c.GuildID(), "feature."+name, "")
+	if err != nil {
+		return false, err
+	}
+	switch override {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	}
+	return c.Bot.cfg.Features.Enabled(name), nil
+}
+
+// isAdmin reports whether the invoking member may manage the guild.
+func (c *Context) isAdmin() (bool, error) {
+	perms, err := c.Session.UserChannelPermissions(c.Message.Author.ID, c.Message.ChannelID)
+	if err != nil {
+		return false, err
+	}
+	return perms&(discordgo.PermissionManageServer|discordgo.PermissionAdministrator) != 0, nil
+}
+
+// Reply sends a message to the channel the command came from, chunking
+// content that exceeds Discord's length limit.
+func (c *Context) Reply(content string) error {
+	if c.onReply != nil {
+		c.onReply(content)
+	}
+	for _, chunk := range render.SplitMessage(content) {
+		if c.send != nil {
+			if err := c.send(chunk); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := c.Session.ChannelMessageSend(c.Message.ChannelID, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplyEmbed sends a rich embed with fallback as the plain-text
+// equivalent. Slash invocations answer with the fallback (their reply
+// path carries text), and an embed send failure — missing permission,
+// API hiccup — degrades to the fallback instead of erroring out.
+func (c *Context) ReplyEmbed(embed *discordgo.MessageEmbed, fallback string) error {
+	if c.send != nil {
+		return c.Reply(fallback)
+	}
+	if _, err := c.Session.ChannelMessageSendEmbed(c.Message.ChannelID, embed); err != nil {
+		return c.Reply(fallback)
+	}
+	return nil
+}
+
+// router maps command names to their definitions, remembering
+// registration order for !help.
+type router struct {
+	commands map[string]*Command
+	order    []string
+}
+
+func newRouter() *router {
+	return &router{commands: make(map[string]*Command)}
+}
+
+func (r *router) register(cmd *Command) {
+	if _, exists := r.commands[cmd.Name]; exists {
+		// Commands self-register at startup; a duplicate name is a
+		// programming error that would silently shadow a handler.
+		panic("bot: duplicate command " + cmd.Name)
+	}
+	r.commands[cmd.Name] = cmd
+	r.order = append(r.order, cmd.Name)
+}
+
+// dispatch parses a prefixed message and runs the matching command.
+// It reports whether the message was a known command.
+func (r *router) dispatch(ctx *Context, prefix, content string) (bool, error) {
+	if !strings.HasPrefix(content, prefix) {
+		return false, nil
+	}
+	fields := strings.Fields(strings.TrimPrefix(content, prefix))
+	if len(fields) == 0 {
+		return false, nil
+	}
+	cmd, ok := r.commands[strings.ToLower(fields[0])]
+	if !ok {
+		return false, nil
+	}
+	ctx.Args = fields[1:]
+	budget := cmd.Budget
+	if budget == 0 {
+		budget = defaultCommandBudget
+	}
+	ctx.deadline = time.Now().Add(budget)
+	if cmd.OperatorOnly {
+		if !ctx.Bot.cfg.IsOperator(ctx.Message.Author.ID) {
+			return true, ctx.Reply("このコマンドはインスタンスの運用者のみ使用できます。")
+		}
+	} else {
+		mode, err := ctx.Bot.store.AppSetting(storage.AppSettingMaintenance, "off")
+		if err != nil {
+			return true, err
+		}
+		if mode == "on" {
+			return true, ctx.Reply("🔧 ただいまメンテナンス中です。しばらくしてからもう一度お試しください。")
+		}
+	}
+	if cmd.Feature != "" {
+		on, err := ctx.featureEnabled(cmd.Feature)
+		if err != nil {
+			return true, err
+		}
+		if !on {
+			return true, ctx.Reply("この機能は現在無効化されています。")
+		}
+	}
+	if cmd.AdminOnly {
+		admin, err := ctx.isAdmin()
+		if err != nil {
+			return true, err
+		}
+		if !admin {
+			return true, ctx.Reply("このコマンドはサーバー管理者のみ使用できます。")
+		}
+	}
+	if cmd.Cacheable {
+		return true, r.dispatchCached(ctx, cmd)
+	}
+	return true, cmd.Handler(ctx)
+}
+
+// dispatchCached answers a cacheable command from the reply cache when
+// the guild's data has not changed, and captures the rendered reply for
+// next time otherwise. Commands that reply more than once are only
+// served from cache when the whole exchange was a single message.
+func (r *router) dispatchCached(ctx *Context, cmd *Command) error {
+	version, err := ctx.Bot.store.GuildDataVersion(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	key := ctx.Bot.replies.key(ctx.GuildID(), cmd.Name, ctx.Args)
+	if content, ok := ctx.Bot.replies.get(key, version); ok {
+		ctx.onReply = nil
+		return ctx.Reply(content)
+	}
+	var captured []string
+	ctx.onReply = func(content string) { captured = append(captured, content) }
+	if err := cmd.Handler(ctx); err != nil {
+		return err
+	}
+	if len(captured) == 1 {
+		ctx.Bot.replies.put(key, version, captured[0])
+	}
+	return nil
+}
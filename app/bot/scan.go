@@ -0,0 +1,188 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// riotIDPattern matches Name#Tag fragments inside nicknames, e.g.
+// "たろう | Taro#JP1".
+var riotIDPattern = regexp.MustCompile(`([\p{L}\p{N}][\p{L}\p{N} ._\-]{1,15})#([A-Za-z0-9]{2,5})`)
+
+// scanMax caps how many suggestions one scan page posts; Discord allows
+// at most five buttons per row and five rows per message, and the last
+// row is reserved for the next-page button.
+const scanMax = 20
+
+// cmdScan inspects member nicknames for Riot ID patterns and suggests
+// unregistered candidates, each behind a confirmation button.
+func cmdScan(ctx *Context) error {
+	members, err := ctx.Session.GuildMembers(ctx.GuildID(), "", 1000)
+	if err != nil {
+		return err
+	}
+	registered, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(registered))
+	for _, p := range registered {
+		known[strings.ToLower(p.RiotID())] = true
+	}
+
+	seen := map[string]bool{}
+	var candidates []string
+	for _, m := range members {
+		if m.User == nil || m.User.Bot {
+			continue
+		}
+		for _, source := range []string{m.Nick, m.User.GlobalName, m.User.Username} {
+			match := riotIDPattern.FindStringSubmatch(source)
+			if match == nil {
+				continue
+			}
+			id := strings.TrimSpace(match[1]) + "#" + match[2]
+			key := strings.ToLower(id)
+			if known[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, id)
+			break
+		}
+	}
+	if len(candidates) == 0 {
+		return ctx.Reply("メンバーの表示名からRiot IDらしき候補は見つかりませんでした。")
+	}
+	return postScanPage(ctx.Session, ctx.Bot, ctx.GuildID(), ctx.Message.ChannelID, candidates, len(candidates))
+}
+
+// postScanPage posts up to scanMax suggestion buttons. When more
+// candidates remain, the rest are persisted keyed by the posted
+// message's ID so the next-page button survives gateway restarts.
+func postScanPage(s *discordgo.Session, b *Bot, guildID, channelID string, candidates []string, total int) error {
+	page := candidates
+	if len(page) > scanMax {
+		page = page[:scanMax]
+	}
+	rest := candidates[len(page):]
+
+	msg := &discordgo.MessageSend{
+		Content: fmt.Sprintf("**ロスター候補** (全%d件中%d件)\n表示名からRiot IDらしきものを見つけました。ボタンで登録できます。", total, len(page)),
+	}
+	var row discordgo.ActionsRow
+	for _, id := range page {
+		row.Components = append(row.Components, discordgo.Button{
+			CustomID: "scan:add:" + id,
+			Label:    id,
+			Style:    discordgo.SecondaryButton,
+		})
+		if len(row.Components) == 5 {
+			msg.Components = append(msg.Components, row)
+			row = discordgo.ActionsRow{}
+		}
+	}
+	if len(row.Components) > 0 {
+		msg.Components = append(msg.Components, row)
+	}
+	if len(rest) > 0 {
+		msg.Components = append(msg.Components, discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				CustomID: "scan:more",
+				Label:    fmt.Sprintf("残り%d件を表示", len(rest)),
+				Style:    discordgo.PrimaryButton,
+			},
+		}})
+	}
+	sent, err := s.ChannelMessageSendComplex(channelID, msg)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(scanState{Candidates: rest, Total: total})
+	if err != nil {
+		return err
+	}
+	return b.store.SaveInteractionState(sent.ID, guildID, "scan", string(payload))
+}
+
+// scanState is the persisted payload behind a scan page's next button.
+type scanState struct {
+	Candidates []string `json:"candidates"`
+	Total      int      `json:"total"`
+}
+
+// handleScanComponent registers the Riot ID behind a suggestion button,
+// or posts the next page of suggestions.
+func (b *Bot) handleScanComponent(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.MessageComponentInteractionData) error {
+	if data.CustomID == "scan:more" {
+		return b.handleScanMore(s, i)
+	}
+	id := strings.TrimPrefix(data.CustomID, "scan:add:")
+	gameName, tagLine, ok := parseRiotID(id)
+	if !ok {
+		return nil
+	}
+	// Reuse the command path: the interaction channel stands in for a
+	// message context.
+	ctx := &Context{
+		Bot:     b,
+		Session: s,
+		Message: &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: i.GuildID, ChannelID: i.ChannelID}},
+	}
+	reply := ""
+	player, added, err := registerRiotID(ctx, gameName, tagLine)
+	switch {
+	case err != nil:
+		reply = userMessage(err)
+	case added:
+		reply = fmt.Sprintf("**%s** を登録しました。", player.RiotID())
+	default:
+		reply = fmt.Sprintf("**%s** はすでに登録されています。", player.RiotID())
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: reply,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleScanMore continues a paginated scan from the state persisted
+// under the button's message, so the button works even after a restart.
+func (b *Bot) handleScanMore(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	state, err := b.store.InteractionState(i.Message.ID)
+	if err != nil {
+		return err
+	}
+	if state == nil || state.Kind != "scan" {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "この候補リストは期限切れです。もう一度 `scan` を実行してください。",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+	var st scanState
+	if err := json.Unmarshal([]byte(state.Payload), &st); err != nil {
+		return err
+	}
+	// Consume the state first so a double click cannot post twice.
+	if err := b.store.DeleteInteractionState(i.Message.ID); err != nil {
+		return err
+	}
+	if err := postScanPage(s, b, i.GuildID, i.ChannelID, st.Candidates, st.Total); err != nil {
+		return err
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+}
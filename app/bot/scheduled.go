@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdPreview renders what a scheduled post would look like right now,
+// in the invoking channel, without touching the announcement channel.
+func cmdPreview(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "preview daily|recap`")
+	}
+	loc, err := guildLocale(ctx)
+	if err != nil {
+		return err
+	}
+	switch ctx.Args[0] {
+	case "daily":
+		format, err := outputFormat(ctx)
+		if err != nil {
+			return err
+		}
+		players, err := ctx.Bot.store.LatestRanks(ctx.GuildID(), riotapi.QueueSolo)
+		if err != nil {
+			return err
+		}
+		return ctx.Reply("【プレビュー】\n" + render.Ranking(players, render.SoloRankingTitle, format, loc))
+	case "recap":
+		day := time.Now().In(render.JST).AddDate(0, 0, -1)
+		from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, render.JST)
+		entries, err := ctx.Bot.store.RecapData(ctx.GuildID(), from, from.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		vision, err := ctx.Bot.store.GuildSetting(ctx.GuildID(), storage.SettingRecapVision, "on")
+		if err != nil {
+			return err
+		}
+		// A preview should not ping anyone.
+		return ctx.Reply("【プレビュー】\n" + render.DailyRecap(from, entries, loc, vision == "on", nil))
+	default:
+		return ctx.Reply("プレビューできるのは `daily` と `recap` です。")
+	}
+}
+
+// cmdPost asks the worker to send a scheduled post immediately.
+func cmdPost(ctx *Context) error {
+	if len(ctx.Args) < 1 {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "post daily|recap`")
+	}
+	var kind string
+	switch ctx.Args[0] {
+	case "daily":
+		kind = storage.TaskPostDaily
+	case "recap":
+		kind = storage.TaskPostRecap
+	default:
+		return ctx.Reply("投稿できるのは `daily` と `recap` です。")
+	}
+	if err := ctx.Bot.store.EnqueueTask(kind, ctx.GuildID()); err != nil {
+		return err
+	}
+	return ctx.Reply("投稿をリクエストしました。まもなく投稿されます。")
+}
@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// scoutMatches is how many recent games a scout report samples. Each
+// uncached match costs one throttled API call, so this bounds how long
+// the command takes on a cold lookup — small enough for champ select.
+const scoutMatches = 5
+
+// scoutTTL is how long a scout report stays fresh; repeat lookups on
+// the same target within it answer instantly from memory.
+const scoutTTL = 10 * time.Minute
+
+type scoutEntry struct {
+	report    string
+	fetchedAt time.Time
+}
+
+// scoutCache holds recent reports keyed by lowercased riot ID.
+var scoutCache = struct {
+	sync.Mutex
+	m map[string]scoutEntry
+}{m: map[string]scoutEntry{}}
+
+// cmdScout looks up an arbitrary (usually unregistered) player's rank,
+// recent champions and winrate before a game.
+func cmdScout(ctx *Context) error {
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "scout <ゲーム名#タグ>` 例: `" +
+			ctx.Bot.cfg.Prefix + "scout Hide on bush#KR1`")
+	}
+	key := strings.ToLower(gameName + "#" + tagLine)
+
+	scoutCache.Lock()
+	cached, hit := scoutCache.m[key]
+	scoutCache.Unlock()
+	if hit && time.Since(cached.fetchedAt) < scoutTTL {
+		return ctx.Reply(cached.report)
+	}
+
+	report, err := buildScoutReport(ctx, gameName, tagLine)
+	if err != nil {
+		return err
+	}
+	scoutCache.Lock()
+	scoutCache.m[key] = scoutEntry{report: report, fetchedAt: time.Now()}
+	scoutCache.Unlock()
+	return ctx.Reply(report)
+}
+
+func buildScoutReport(ctx *Context, gameName, tagLine string) (string, error) {
+	account, err := ctx.Bot.riot.AccountByRiotID(gameName, tagLine)
+	if errors.Is(err, riotapi.ErrNotFound) {
+		return fmt.Sprintf("**%s#%s** が見つかりませんでした。", gameName, tagLine), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	platform, err := detectPlatform(ctx, account.PUUID)
+	if err != nil {
+		return "", err
+	}
+	riot := ctx.Bot.riot.ForPlatform(platform)
+
+	summoner, err := riot.SummonerByPUUID(account.PUUID)
+	if err != nil {
+		return "", err
+	}
+	entries, err := riot.LeagueEntriesBySummonerID(summoner.ID)
+	if err != nil {
+		return "", err
+	}
+	display := "ランクなし"
+	wins, losses := 0, 0
+	for _, e := range entries {
+		if e.QueueType == riotapi.QueueSolo {
+			display = rank.Format(e.Tier, e.Rank, e.LeaguePoints)
+			wins, losses = e.Wins, e.Losses
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s#%s** (%s)\n", account.GameName, account.TagLine, platform)
+	fmt.Fprintf(&b, "ソロランク: %s", display)
+	if wins+losses > 0 {
+		fmt.Fprintf(&b, " — 今シーズン %d勝%d敗 (%.0f%%)",
+			wins, losses, float64(wins)/float64(wins+losses)*100)
+	}
+	b.WriteString("\n")
+
+	champs, err := scoutRecentChamps(ctx, riot, account.PUUID)
+	if err != nil {
+		return "", err
+	}
+	if champs != "" {
+		b.WriteString("直近のチャンピオン: " + champs)
+	}
+	return b.String(), nil
+}
+
+// scoutRecentChamps samples the target's latest solo-queue games and
+// summarizes picks. Matches the guild has already cached are read from
+// the store instead of the API.
+func scoutRecentChamps(ctx *Context, riot *riotapi.Client, puuid string) (string, error) {
+	ids, err := riot.MatchIDsByPUUID(puuid, 0, 0, scoutMatches)
+	if err != nil {
+		return "", err
+	}
+	type pick struct {
+		champ string
+		win   bool
+	}
+	var picks []pick
+	for _, id := range ids {
+		m, err := ctx.Bot.store.MatchByID(id)
+		if err != nil {
+			return "", err
+		}
+		if m == nil {
+			if m, err = riot.Match(id); err != nil {
+				return "", err
+			}
+			if err := ctx.Bot.store.CacheMatch(m); err != nil {
+				return "", err
+			}
+		}
+		for i := range m.Info.Participants {
+			if m.Info.Participants[i].PUUID == puuid {
+				picks = append(picks, pick{m.Info.Participants[i].ChampionName, m.Info.Participants[i].Win})
+				break
+			}
+		}
+	}
+	if len(picks) == 0 {
+		return "", nil
+	}
+	counts := map[string]int{}
+	wins := map[string]int{}
+	for _, p := range picks {
+		counts[p.champ]++
+		if p.win {
+			wins[p.champ]++
+		}
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s (%d勝%d敗)", name, wins[name], counts[name]-wins[name]))
+	}
+	return strings.Join(parts, ", "), nil
+}
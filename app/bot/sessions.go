@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// sessionGap is the idle time that splits two games into separate play
+// sessions; sessionWindowDays is the reporting window.
+const (
+	sessionGap        = time.Hour
+	sessionWindowDays = 7
+)
+
+// playSession is one run of games without a break longer than
+// sessionGap.
+type playSession struct {
+	start, end time.Time
+	games      int
+}
+
+func (s *playSession) length() time.Duration { return s.end.Sub(s.start) }
+
+// playerSessions groups a player's cached games in [from, to) into play
+// sessions, oldest first.
+func playerSessions(store *storage.Store, player *storage.Player, from, to time.Time) ([]playSession, error) {
+	matches, err := store.MatchesForPlayer(player.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	type game struct{ start, end time.Time }
+	games := make([]game, 0, len(matches))
+	for _, m := range matches {
+		end := time.UnixMilli(m.Info.GameEndTimestamp)
+		games = append(games, game{start: end.Add(-time.Duration(m.Info.GameDuration) * time.Second), end: end})
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].start.Before(games[j].start) })
+
+	var sessions []playSession
+	for _, g := range games {
+		if n := len(sessions); n > 0 && g.start.Sub(sessions[n-1].end) <= sessionGap {
+			sessions[n-1].end = g.end
+			sessions[n-1].games++
+			continue
+		}
+		sessions = append(sessions, playSession{start: g.start, end: g.end, games: 1})
+	}
+	return sessions, nil
+}
+
+// cmdSessions reports a player's play-session habits over the last
+// week: how often they sit down, for how long, and the longest stretch.
+func cmdSessions(ctx *Context) error {
+	if len(ctx.Args) == 0 {
+		return errUsage("使い方: `" + ctx.Bot.cfg.Prefix + "sessions <ゲーム名#タグ>`")
+	}
+	gameName, tagLine, ok := parseRiotID(strings.Join(ctx.Args, " "))
+	if !ok {
+		return errUsage("Riot IDは `ゲーム名#タグ` の形式で指定してください。")
+	}
+	player, err := ctx.Bot.store.PlayerByRiotID(ctx.GuildID(), gameName, tagLine)
+	if errors.Is(err, storage.ErrNotRegistered) {
+		return ctx.Reply(fmt.Sprintf("**%s#%s** は登録されていません。", gameName, tagLine))
+	}
+	if err != nil {
+		return err
+	}
+	to := time.Now()
+	sessions, err := playerSessions(ctx.Bot.store, player, to.AddDate(0, 0, -sessionWindowDays), to)
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return ctx.Reply(fmt.Sprintf("直近%d日のプレイ記録がありません。", sessionWindowDays))
+	}
+
+	var total time.Duration
+	totalGames := 0
+	longest := &sessions[0]
+	for i := range sessions {
+		total += sessions[i].length()
+		totalGames += sessions[i].games
+		if sessions[i].length() > longest.length() {
+			longest = &sessions[i]
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s のプレイセッション** (直近%d日)\n", player.RiotID(), sessionWindowDays)
+	fmt.Fprintf(&b, "・セッション数: %d回 (計%d試合)\n", len(sessions), totalGames)
+	fmt.Fprintf(&b, "・平均: %s (%.1f試合)\n", sessionDuration(total/time.Duration(len(sessions))), float64(totalGames)/float64(len(sessions)))
+	fmt.Fprintf(&b, "・最長: %s (%s、%d試合)",
+		sessionDuration(longest.length()), longest.start.In(render.JST).Format("01/02"), longest.games)
+	return ctx.Reply(b.String())
+}
+
+// sessionDuration renders a session length as hours and minutes.
+func sessionDuration(d time.Duration) string {
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	if h == 0 {
+		return fmt.Sprintf("%d分", m)
+	}
+	return fmt.Sprintf("%d時間%d分", h, m)
+}
@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/web"
+)
+
+// shareDefaultDays and shareMaxDays bound how long a public link lives.
+const (
+	shareDefaultDays = 7
+	shareMaxDays     = 30
+)
+
+// cmdShare hands out a signed, expiring public URL for the current
+// leaderboard, rendered by the worker's HTTP server.
+func cmdShare(ctx *Context) error {
+	if ctx.Bot.cfg.ShareSecret == "" || ctx.Bot.cfg.PublicBaseURL == "" {
+		return ctx.Reply("共有リンクは設定されていません（SHARE_SECRET と PUBLIC_BASE_URL が必要です）。")
+	}
+	if len(ctx.Args) < 1 || ctx.Args[0] != "ranking" {
+		return ctx.Reply("使い方: `" + ctx.Bot.cfg.Prefix + "share ranking [日数]`")
+	}
+	days := shareDefaultDays
+	if len(ctx.Args) >= 2 {
+		if _, err := fmt.Sscanf(ctx.Args[1], "%d", &days); err != nil || days < 1 || days > shareMaxDays {
+			return ctx.Reply(fmt.Sprintf("日数は1〜%dで指定してください。", shareMaxDays))
+		}
+	}
+	expires := time.Now().AddDate(0, 0, days)
+	url := web.ShareURL(ctx.Bot.cfg.PublicBaseURL, ctx.Bot.cfg.ShareSecret, ctx.GuildID(), expires)
+	return ctx.Reply(fmt.Sprintf("現在のランキングの閲覧専用リンクです（%sまで有効）:\n<%s>",
+		expires.In(render.JST).Format("2006/01/02 15:04"), url))
+}
@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// slashOptionOrder fixes the positional order in which each slash
+// command's options are folded back into text-command arguments, since
+// Discord delivers options in whatever order the user filled them in.
+var slashOptionOrder = map[string][]string{
+	"ranking":  {"filter"},
+	"rank":     {"player"},
+	"daystats": {"player", "date"},
+	"add":      {"player"},
+}
+
+// applicationCommands returns the slash command definitions this bot
+// registers with Discord. Text commands are the primary interface, so
+// the list only holds commands that also have a slash form; both routes
+// end up in the same handlers.
+func (b *Bot) applicationCommands() []*discordgo.ApplicationCommand {
+	opt := func(name, desc string, required bool) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        name,
+			Description: desc,
+			Required:    required,
+		}
+	}
+	cmds := []*discordgo.ApplicationCommand{
+		{Name: "ranking", Options: []*discordgo.ApplicationCommandOption{
+			opt("filter", "role:<ロール> や --compact などの絞り込み", false),
+		}},
+		{Name: "rank", Options: []*discordgo.ApplicationCommandOption{
+			opt("player", "ゲーム名#タグ", true),
+		}},
+		{Name: "daystats", Options: []*discordgo.ApplicationCommandOption{
+			opt("player", "ゲーム名#タグ", true),
+			opt("date", "YYYYMMDD（省略すると今日＋日付メニュー）", false),
+		}},
+		{Name: "add", Options: []*discordgo.ApplicationCommandOption{
+			opt("player", "ゲーム名#タグ", true),
+		}},
+	}
+	for _, c := range cmds {
+		c.Description = b.router.commands[c.Name].Help
+	}
+	return cmds
+}
+
+// syncApplicationCommands overwrites Discord's view of the slash
+// commands with the current definitions. Failures are logged, not
+// fatal: the text commands keep working and !resync can retry.
+func (b *Bot) syncApplicationCommands() {
+	cmds := b.applicationCommands()
+	if _, err := b.session.ApplicationCommandBulkOverwrite(b.session.State.User.ID, "", cmds); err != nil {
+		log.Printf("gateway: slash command sync: %v", err)
+		return
+	}
+	log.Printf("gateway: registered %d slash commands", len(cmds))
+}
+
+// handleApplicationCommand runs a slash-command invocation through the
+// same router as text commands. The response is deferred immediately —
+// handlers may hit the Riot API and Discord fails interactions that
+// stay silent for three seconds.
+func (b *Bot) handleApplicationCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if _, ok := b.router.commands[data.Name]; !ok {
+		return
+	}
+	if i.GuildID == "" || i.Member == nil {
+		if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "スラッシュコマンドはサーバー内でのみ使用できます。",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		}); err != nil {
+			log.Printf("gateway: slash reply failed: %v", err)
+		}
+		return
+	}
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		log.Printf("gateway: slash defer: %v", err)
+		return
+	}
+	replied := false
+	ctx := &Context{
+		Bot:     b,
+		Session: s,
+		Message: &discordgo.MessageCreate{Message: &discordgo.Message{
+			GuildID:   i.GuildID,
+			ChannelID: i.ChannelID,
+			Author:    i.Member.User,
+		}},
+		send: func(content string) error {
+			if replied {
+				_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{Content: content})
+				return err
+			}
+			replied = true
+			_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content})
+			return err
+		},
+	}
+	line := b.cfg.Prefix + data.Name
+	if args := slashArgs(data); len(args) > 0 {
+		line += " " + strings.Join(args, " ")
+	}
+	if _, err := b.router.dispatch(ctx, b.cfg.Prefix, line); err != nil {
+		log.Printf("gateway: slash command error: %v", err)
+		if err := ctx.Reply(userMessage(err)); err != nil {
+			log.Printf("gateway: slash reply failed: %v", err)
+		}
+		return
+	}
+	// Handlers that post straight to the channel (component menus,
+	// file uploads) never touch the deferred reply; drop it so the
+	// invocation doesn't hang on "考え中...".
+	if !replied {
+		if err := s.InteractionResponseDelete(i.Interaction); err != nil {
+			log.Printf("gateway: slash cleanup: %v", err)
+		}
+	}
+}
+
+// slashArgs flattens a slash invocation's options into the token list
+// the text-command parser expects.
+func slashArgs(data discordgo.ApplicationCommandInteractionData) []string {
+	byName := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	for _, o := range data.Options {
+		byName[o.Name] = o
+	}
+	var args []string
+	for _, name := range slashOptionOrder[data.Name] {
+		if o, ok := byName[name]; ok {
+			args = append(args, strings.Fields(o.StringValue())...)
+		}
+	}
+	return args
+}
@@ -0,0 +1,42 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// cmdValidate reports the health of every roster entry, surfacing the
+// account states the worker's refresh cycle has flagged instead of
+// letting them fail silently every hour.
+func cmdValidate(ctx *Context) error {
+	players, err := ctx.Bot.store.Players(ctx.GuildID())
+	if err != nil {
+		return err
+	}
+	if len(players) == 0 {
+		return ctx.Reply("登録済みプレイヤーがいません。")
+	}
+	now := time.Now()
+	var b strings.Builder
+	b.WriteString("**ロスター診断**\n")
+	healthy := 0
+	for i := range players {
+		p := &players[i]
+		switch {
+		case p.State == storage.StateMissing:
+			fmt.Fprintf(&b, "❓ %s — APIで見つかりません（リージョン移管・BAN・リネームの可能性）。`%sadd` で再登録してください。\n",
+				p.RiotID(), ctx.Bot.cfg.Prefix)
+		case p.State == storage.StateInactive:
+			fmt.Fprintf(&b, "💤 %s — 長期間ランク戦の記録がありません。\n", p.RiotID())
+		case p.IsPaused(now):
+			fmt.Fprintf(&b, "⏸ %s — 休止中です。\n", p.RiotID())
+		default:
+			healthy++
+		}
+	}
+	fmt.Fprintf(&b, "✅ 正常: %d / %d", healthy, len(players))
+	return ctx.Reply(b.String())
+}
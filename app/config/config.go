@@ -0,0 +1,132 @@
+// Package config loads process-wide settings from the environment.
+// The same configuration is shared by the gateway and worker processes.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/keny-N/lol-ranking/app/feature"
+)
+
+// Config holds everything both processes need to start up.
+type Config struct {
+	DiscordToken string
+	RiotAPIKey   string
+	DBPath       string
+	// Platform is the platform routing value (jp1, kr, na1, ...).
+	Platform string
+	// Region is the regional routing value used by match-v5 (asia, americas, europe).
+	Region string
+	// RegionFallbacks are alternate regional routing values tried when
+	// the primary regional host is degraded, parsed from the
+	// comma-separated RIOT_REGION_FALLBACKS environment variable.
+	RegionFallbacks []string
+	// AnnounceChannelID is a fallback announcement channel for guilds
+	// that have not configured one of their own.
+	AnnounceChannelID string
+	Prefix            string
+	// MatchRetentionDays and SnapshotRetentionDays bound how long the
+	// worker's maintenance job keeps cached data.
+	MatchRetentionDays    int
+	SnapshotRetentionDays int
+	// Features are the operator-level feature flag states, parsed from
+	// the FEATURES environment variable (e.g. "live,-scout").
+	Features feature.Set
+	// HTTPAddr is where the worker's HTTP server (WebSocket event push)
+	// listens; empty disables it.
+	HTTPAddr string
+	// ShareSecret signs public share links and PublicBaseURL is the
+	// externally reachable prefix of the HTTP server; both must be set
+	// for !share to work.
+	ShareSecret   string
+	PublicBaseURL string
+	// XAccessToken enables milestone tweets when set;
+	// XMilestoneTemplate overrides the default tweet text
+	// ({player} and {rank} are substituted).
+	XAccessToken       string
+	XMilestoneTemplate string
+	// SecretKey, when set, encrypts sensitive stored values (guild API
+	// keys) at rest; both processes must share the same SECRET_KEY.
+	SecretKey string
+	// OperatorIDs are the Discord user IDs allowed to run instance
+	// management commands (!maintenance etc.), parsed from the
+	// comma-separated OPERATOR_IDS environment variable.
+	OperatorIDs []string
+}
+
+// IsOperator reports whether a Discord user may manage this instance.
+func (c *Config) IsOperator(userID string) bool {
+	for _, id := range c.OperatorIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads the configuration from environment variables and validates
+// that the required secrets are present.
+func Load() (*Config, error) {
+	c := &Config{
+		DiscordToken:      os.Getenv("DISCORD_TOKEN"),
+		RiotAPIKey:        os.Getenv("RIOT_API_KEY"),
+		DBPath:            envOr("DB_PATH", "lolranking.db"),
+		Platform:          envOr("RIOT_PLATFORM", "jp1"),
+		Region:            envOr("RIOT_REGION", "asia"),
+		AnnounceChannelID: os.Getenv("ANNOUNCE_CHANNEL_ID"),
+		Prefix:            envOr("COMMAND_PREFIX", "!"),
+		Features:          feature.Parse(os.Getenv("FEATURES")),
+		HTTPAddr:          os.Getenv("HTTP_ADDR"),
+		ShareSecret:       os.Getenv("SHARE_SECRET"),
+		PublicBaseURL:     strings.TrimRight(os.Getenv("PUBLIC_BASE_URL"), "/"),
+		XAccessToken:      os.Getenv("X_ACCESS_TOKEN"),
+	}
+	c.XMilestoneTemplate = os.Getenv("X_MILESTONE_TEMPLATE")
+	c.SecretKey = os.Getenv("SECRET_KEY")
+	for _, id := range strings.Split(os.Getenv("OPERATOR_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			c.OperatorIDs = append(c.OperatorIDs, id)
+		}
+	}
+	for _, region := range strings.Split(os.Getenv("RIOT_REGION_FALLBACKS"), ",") {
+		if region = strings.TrimSpace(region); region != "" && region != c.Region {
+			c.RegionFallbacks = append(c.RegionFallbacks, region)
+		}
+	}
+	var err error
+	if c.MatchRetentionDays, err = envInt("MATCH_RETENTION_DAYS", 90); err != nil {
+		return nil, err
+	}
+	if c.SnapshotRetentionDays, err = envInt("SNAPSHOT_RETENTION_DAYS", 400); err != nil {
+		return nil, err
+	}
+	if c.DiscordToken == "" {
+		return nil, fmt.Errorf("DISCORD_TOKEN is not set")
+	}
+	if c.RiotAPIKey == "" {
+		return nil, fmt.Errorf("RIOT_API_KEY is not set")
+	}
+	return c, nil
+}
+
+func envInt(key string, fallback int) (int, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return n, nil
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
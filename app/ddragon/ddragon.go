@@ -0,0 +1,144 @@
+// Package ddragon resolves champion IDs to display names using Riot's
+// Data Dragon CDN. It is built to degrade, never to fail: a bundled
+// snapshot answers before the first fetch completes, and when the CDN
+// is unreachable the last successful dataset — however stale — keeps
+// serving, so stats commands stay up through CDN outages.
+package ddragon
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// refreshInterval is how long a fetched dataset is considered fresh.
+// Champion releases are rare, so a day of staleness is invisible.
+const refreshInterval = 24 * time.Hour
+
+// retryInterval spaces refresh attempts after a failure so an outage
+// doesn't add a timeout to every lookup.
+const retryInterval = 10 * time.Minute
+
+const (
+	versionsURL    = "https://ddragon.leagueoflegends.com/api/versions.json"
+	championURLFmt = "https://ddragon.leagueoflegends.com/cdn/%s/data/en_US/champion.json"
+	iconURLFmt     = "https://ddragon.leagueoflegends.com/cdn/%s/img/champion/%s.png"
+)
+
+// Resolver maps champion IDs to names, refreshing itself from the CDN
+// in the background of lookups.
+type Resolver struct {
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	names       map[int64]string
+	version     string
+	fetchedAt   time.Time // zero until the first successful fetch
+	lastAttempt time.Time
+}
+
+// NewResolver builds a Resolver pre-loaded with the bundled snapshot.
+func NewResolver() *Resolver {
+	names := make(map[int64]string, len(bundledChampions))
+	for id, name := range bundledChampions {
+		names[id] = name
+	}
+	return &Resolver{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		names:      names,
+		version:    bundledVersion,
+	}
+}
+
+// ChampionName resolves a champion ID, refreshing the dataset first if
+// it has gone stale. Unknown IDs (new champions while offline) render
+// as a numbered placeholder rather than an error.
+func (r *Resolver) ChampionName(id int64) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshLocked()
+	if name, ok := r.names[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("Champion#%d", id)
+}
+
+// Version reports the dataset version currently in use.
+func (r *Resolver) Version() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.version
+}
+
+// IconURL is the CDN icon for a champion by its ddragon identifier
+// (the name without spaces or punctuation, e.g. "MissFortune").
+func (r *Resolver) IconURL(identifier string) string {
+	return fmt.Sprintf(iconURLFmt, r.Version(), identifier)
+}
+
+// refreshLocked fetches the live dataset when due. Failures are logged
+// and leave the previous dataset — fetched or bundled — in place.
+func (r *Resolver) refreshLocked() {
+	now := time.Now()
+	if now.Sub(r.fetchedAt) < refreshInterval || now.Sub(r.lastAttempt) < retryInterval {
+		return
+	}
+	r.lastAttempt = now
+	version, names, err := r.fetch()
+	if err != nil {
+		log.Printf("ddragon: refresh failed, keeping %s dataset: %v", r.version, err)
+		return
+	}
+	r.version = version
+	r.names = names
+	r.fetchedAt = now
+}
+
+func (r *Resolver) fetch() (string, map[int64]string, error) {
+	var versions []string
+	if err := r.getJSON(versionsURL, &versions); err != nil {
+		return "", nil, err
+	}
+	if len(versions) == 0 {
+		return "", nil, fmt.Errorf("empty versions list")
+	}
+	version := versions[0]
+
+	var payload struct {
+		Data map[string]struct {
+			Key  string `json:"key"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := r.getJSON(fmt.Sprintf(championURLFmt, version), &payload); err != nil {
+		return "", nil, err
+	}
+	names := make(map[int64]string, len(payload.Data))
+	for _, champ := range payload.Data {
+		id, err := strconv.ParseInt(champ.Key, 10, 64)
+		if err != nil {
+			continue
+		}
+		names[id] = champ.Name
+	}
+	if len(names) == 0 {
+		return "", nil, fmt.Errorf("empty champion dataset")
+	}
+	return version, names, nil
+}
+
+func (r *Resolver) getJSON(url string, v interface{}) error {
+	resp, err := r.httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,57 @@
+// Package feature resolves bot-wide feature flags so operators can turn
+// experimental subsystems on and off without code changes. The master
+// switches come from the FEATURES environment variable; guilds may
+// further opt in or out via `feature.<name>` settings.
+package feature
+
+import "strings"
+
+// Known flag names. Each gates one experimental subsystem.
+const (
+	// Scout gates the pre-game opponent lookup command.
+	Scout = "scout"
+	// Live gates live-game spectating lookups.
+	Live = "live"
+)
+
+// defaults is the shipped state of every known flag.
+var defaults = map[string]bool{
+	Scout: true,
+	Live:  true,
+}
+
+// Set holds the resolved state of every known flag.
+type Set map[string]bool
+
+// Parse applies a comma-separated spec like "live,-scout" on top of the
+// defaults: a bare name enables a flag, a "-" prefix disables it.
+// Unknown names are ignored so stale specs never break startup.
+func Parse(spec string) Set {
+	s := make(Set, len(defaults))
+	for name, on := range defaults {
+		s[name] = on
+	}
+	for _, token := range strings.Split(spec, ",") {
+		token = strings.TrimSpace(strings.ToLower(token))
+		if token == "" {
+			continue
+		}
+		on := true
+		if strings.HasPrefix(token, "-") {
+			on, token = false, token[1:]
+		}
+		if _, known := defaults[token]; known {
+			s[token] = on
+		}
+	}
+	return s
+}
+
+// Enabled reports whether a flag is on. Unknown names are off.
+func (s Set) Enabled(name string) bool { return s[name] }
+
+// Known reports whether a flag name exists.
+func Known(name string) bool {
+	_, ok := defaults[name]
+	return ok
+}
@@ -0,0 +1,22 @@
+package feature
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec string
+		name string
+		want bool
+	}{
+		{"", Live, true},
+		{"-live", Live, false},
+		{"-live, live", Live, true},
+		{"nosuchflag", "nosuchflag", false},
+		{"-scout", Live, true},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.spec).Enabled(tt.name); got != tt.want {
+			t.Errorf("Parse(%q).Enabled(%q) = %v, want %v", tt.spec, tt.name, got, tt.want)
+		}
+	}
+}
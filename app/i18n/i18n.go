@@ -0,0 +1,90 @@
+// Package i18n formats dates, durations and numbers according to a
+// guild's display locale so output builders don't hard-code Japanese
+// conventions.
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale selects the formatting conventions for user-facing output.
+type Locale string
+
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+)
+
+// Parse maps a stored locale setting to a Locale, defaulting to
+// Japanese for unknown values.
+func Parse(s string) Locale {
+	if strings.EqualFold(s, string(English)) {
+		return English
+	}
+	return Japanese
+}
+
+// Date renders a calendar date: 2024/06/01 or Jun 1, 2024.
+func (l Locale) Date(t time.Time) string {
+	if l == English {
+		return t.Format("Jan 2, 2006")
+	}
+	return t.Format("2006/01/02")
+}
+
+// DateTime renders a date with wall-clock time.
+func (l Locale) DateTime(t time.Time) string {
+	if l == English {
+		return t.Format("Jan 2, 2006 15:04")
+	}
+	return t.Format("2006/01/02 15:04")
+}
+
+// AsOf renders the freshness footer attached to snapshot-based output.
+func (l Locale) AsOf(t time.Time) string {
+	if l == English {
+		return "as of " + l.DateTime(t)
+	}
+	return l.DateTime(t) + " 時点"
+}
+
+// WinLoss renders a win/loss pair: 3勝2敗 or 3W 2L.
+func (l Locale) WinLoss(wins, losses int) string {
+	if l == English {
+		return fmt.Sprintf("%dW %dL", wins, losses)
+	}
+	return fmt.Sprintf("%d勝%d敗", wins, losses)
+}
+
+// Duration renders a game-length duration: 32分05秒 or 32m05s.
+func (l Locale) Duration(d time.Duration) string {
+	m := int(d.Minutes())
+	s := int(d.Seconds()) % 60
+	if l == English {
+		return fmt.Sprintf("%dm%02ds", m, s)
+	}
+	return fmt.Sprintf("%d分%02d秒", m, s)
+}
+
+// Number groups thousands with commas: 12,345.
+func (l Locale) Number(n int) string {
+	s := strconv.Itoa(n)
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	var parts []string
+	for len(s) > 3 {
+		parts = append([]string{s[len(s)-3:]}, parts...)
+		s = s[:len(s)-3]
+	}
+	parts = append([]string{s}, parts...)
+	out := strings.Join(parts, ",")
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
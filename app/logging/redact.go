@@ -0,0 +1,59 @@
+// Package logging provides a redacting writer the processes install as
+// the log output, so secrets and PII never end up in log files even
+// when request URLs or response bodies are logged verbatim.
+package logging
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+)
+
+const placeholder = "[REDACTED]"
+
+// puuidPattern matches PUUIDs and summoner IDs embedded in Riot API
+// request paths.
+var puuidPattern = regexp.MustCompile(`(by-puuid/|by-summoner/|by-riot-id/)[^\s?"]+`)
+
+// apiKeyPattern matches Riot API keys wherever they appear, including
+// api_key query parameters.
+var apiKeyPattern = regexp.MustCompile(`RGAPI-[0-9a-fA-F-]+`)
+
+// Redactor is an io.Writer that scrubs configured secrets and known
+// PII patterns from everything written through it.
+type Redactor struct {
+	out     io.Writer
+	secrets [][]byte
+}
+
+// NewRedactor wraps out; every non-empty secret is replaced with a
+// placeholder in the output.
+func NewRedactor(out io.Writer, secrets ...string) *Redactor {
+	r := &Redactor{out: out}
+	for _, s := range secrets {
+		if s != "" {
+			r.secrets = append(r.secrets, []byte(s))
+		}
+	}
+	return r
+}
+
+// Write implements io.Writer. It reports the original length so the
+// log package never sees a short write.
+func (r *Redactor) Write(p []byte) (int, error) {
+	scrubbed := r.scrub(p)
+	if _, err := r.out.Write(scrubbed); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (r *Redactor) scrub(p []byte) []byte {
+	out := p
+	for _, s := range r.secrets {
+		out = bytes.ReplaceAll(out, s, []byte(placeholder))
+	}
+	out = apiKeyPattern.ReplaceAll(out, []byte(placeholder))
+	out = puuidPattern.ReplaceAll(out, []byte("${1}"+placeholder))
+	return out
+}
@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRedactorScrubsSecretsAndPII(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRedactor(&buf, "super-secret-token", "")
+
+	lines := []string{
+		"authorization: Bot super-secret-token",
+		"GET /riot/account/v1/accounts/by-riot-id/Hide%20on%20bush/KR1 failed",
+		"GET /lol/match/v5/matches/by-puuid/AbC123_-xyz/ids?count=20",
+		"key RGAPI-12345678-abcd-ef01-2345-67890abcdef0 rejected",
+	}
+	for _, l := range lines {
+		if _, err := r.Write([]byte(l + "\n")); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	got := buf.String()
+	for _, leaked := range []string{
+		"super-secret-token",
+		"Hide%20on%20bush",
+		"AbC123_-xyz",
+		"RGAPI-12345678",
+	} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("output still contains %q:\n%s", leaked, got)
+		}
+	}
+	if !strings.Contains(got, "[REDACTED]") {
+		t.Errorf("output has no redaction marker:\n%s", got)
+	}
+}
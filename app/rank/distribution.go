@@ -0,0 +1,59 @@
+package rank
+
+import "strings"
+
+// DistributionPatch is the game patch the share table below was
+// sampled for; bump it together with the numbers.
+const DistributionPatch = "14.10"
+
+// tierShare is one bucket of the global solo-queue rank distribution:
+// the approximate percentage of the ranked population in it.
+type tierShare struct {
+	tier     string
+	division string // empty for apex tiers
+	share    float64
+}
+
+// distribution lists buckets from lowest to highest. The numbers are a
+// static community-sourced snapshot, good enough for rough percentile
+// context; they are normalized at lookup time so they need not sum to
+// exactly 100.
+var distribution = []tierShare{
+	{"IRON", "IV", 3.0}, {"IRON", "III", 2.0}, {"IRON", "II", 2.0}, {"IRON", "I", 1.7},
+	{"BRONZE", "IV", 3.5}, {"BRONZE", "III", 3.0}, {"BRONZE", "II", 3.3}, {"BRONZE", "I", 3.3},
+	{"SILVER", "IV", 4.0}, {"SILVER", "III", 3.5}, {"SILVER", "II", 3.8}, {"SILVER", "I", 3.5},
+	{"GOLD", "IV", 4.5}, {"GOLD", "III", 3.5}, {"GOLD", "II", 3.5}, {"GOLD", "I", 3.0},
+	{"PLATINUM", "IV", 4.5}, {"PLATINUM", "III", 3.0}, {"PLATINUM", "II", 2.7}, {"PLATINUM", "I", 2.2},
+	{"EMERALD", "IV", 4.0}, {"EMERALD", "III", 2.0}, {"EMERALD", "II", 1.5}, {"EMERALD", "I", 1.0},
+	{"DIAMOND", "IV", 1.2}, {"DIAMOND", "III", 0.6}, {"DIAMOND", "II", 0.45}, {"DIAMOND", "I", 0.35},
+	{"MASTER", "", 0.5}, {"GRANDMASTER", "", 0.05}, {"CHALLENGER", "", 0.01},
+}
+
+// Percentile reports roughly what top-% of the ranked population a
+// tier/division sits at (smaller is better), counting half of the
+// player's own bucket as above them. ok is false for unknown ranks.
+func Percentile(tier, division string) (topPercent float64, ok bool) {
+	tier = strings.ToUpper(tier)
+	division = strings.ToUpper(division)
+	if tierIndex(tier) >= tierIndex("MASTER") {
+		division = ""
+	}
+	var total, above float64
+	found := false
+	for _, b := range distribution {
+		total += b.share
+	}
+	for i := len(distribution) - 1; i >= 0; i-- {
+		b := distribution[i]
+		if b.tier == tier && b.division == division {
+			above += b.share / 2
+			found = true
+			break
+		}
+		above += b.share
+	}
+	if !found || total == 0 {
+		return 0, false
+	}
+	return above / total * 100, true
+}
@@ -0,0 +1,23 @@
+package rank
+
+import "testing"
+
+func TestPercentile(t *testing.T) {
+	gold, ok := Percentile("GOLD", "II")
+	if !ok {
+		t.Fatal("GOLD II should be in the distribution")
+	}
+	chall, ok := Percentile("CHALLENGER", "")
+	if !ok {
+		t.Fatal("CHALLENGER should be in the distribution")
+	}
+	if chall >= gold {
+		t.Errorf("Challenger (%.2f%%) should rank above Gold II (%.2f%%)", chall, gold)
+	}
+	if chall <= 0 || gold >= 100 {
+		t.Errorf("percentiles out of range: chall=%.2f gold=%.2f", chall, gold)
+	}
+	if _, ok := Percentile("WOOD", "V"); ok {
+		t.Error("unknown tier should not resolve")
+	}
+}
@@ -0,0 +1,116 @@
+// Package rank converts Riot tier/division/LP triples into comparable
+// scores and display strings.
+package rank
+
+import (
+	"strconv"
+	"strings"
+)
+
+var tierOrder = []string{
+	"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM",
+	"EMERALD", "DIAMOND", "MASTER", "GRANDMASTER", "CHALLENGER",
+}
+
+var divisionOrder = map[string]int{"IV": 0, "III": 1, "II": 2, "I": 3}
+
+// Value collapses tier, division and LP into a single comparable score.
+// Each division spans 100 points, each tier four divisions; apex tiers
+// (Master and above) share a single division and stack LP on top.
+func Value(tier, division string, lp int) int {
+	ti := tierIndex(tier)
+	if ti < 0 {
+		return -1 // unranked sorts below everyone
+	}
+	if ti >= tierIndex("MASTER") {
+		return tierIndex("MASTER")*400 + lp
+	}
+	return ti*400 + divisionOrder[strings.ToUpper(division)]*100 + lp
+}
+
+// Format renders a tier/division/LP triple for display, e.g.
+// "GOLD II 54LP" or "MASTER 120LP".
+func Format(tier, division string, lp int) string {
+	if tier == "" {
+		return "ランクなし"
+	}
+	var b strings.Builder
+	b.WriteString(tier)
+	if tierIndex(tier) < tierIndex("MASTER") && division != "" {
+		b.WriteString(" ")
+		b.WriteString(division)
+	}
+	b.WriteString(" ")
+	b.WriteString(strconv.Itoa(lp))
+	b.WriteString("LP")
+	return b.String()
+}
+
+// FromValue reverses Value, mapping a comparable score (for example an
+// average over several players) back to an approximate tier, division
+// and LP. Negative values mean unranked and return an empty tier.
+func FromValue(value int) (tier, division string, lp int) {
+	if value < 0 {
+		return "", "", 0
+	}
+	if value >= tierIndex("MASTER")*400 {
+		return "MASTER", "", value - tierIndex("MASTER")*400
+	}
+	divisions := []string{"IV", "III", "II", "I"}
+	return tierOrder[value/400], divisions[value%400/100], value % 100
+}
+
+// NormalizeTier maps a user-typed tier name to its canonical form,
+// reporting false for unknown tiers.
+func NormalizeTier(s string) (string, bool) {
+	up := strings.ToUpper(strings.TrimSpace(s))
+	if tierIndex(up) < 0 {
+		return "", false
+	}
+	return up, true
+}
+
+// NormalizeDivision maps "4", "iv" etc. to the roman division form,
+// reporting false for anything else.
+func NormalizeDivision(s string) (string, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "IV", "4":
+		return "IV", true
+	case "III", "3":
+		return "III", true
+	case "II", "2":
+		return "II", true
+	case "I", "1":
+		return "I", true
+	default:
+		return "", false
+	}
+}
+
+// shortTiers abbreviates tiers for compact output.
+var shortTiers = map[string]string{
+	"IRON": "I", "BRONZE": "B", "SILVER": "S", "GOLD": "G", "PLATINUM": "P",
+	"EMERALD": "E", "DIAMOND": "D", "MASTER": "M", "GRANDMASTER": "GM", "CHALLENGER": "CH",
+}
+
+// Short renders a compact rank like "G2 54" or "M 120".
+func Short(tier, division string, lp int) string {
+	if tier == "" {
+		return "無"
+	}
+	short := shortTiers[strings.ToUpper(tier)]
+	if tierIndex(tier) < tierIndex("MASTER") {
+		short += strconv.Itoa(4 - divisionOrder[strings.ToUpper(division)])
+	}
+	return short + " " + strconv.Itoa(lp)
+}
+
+func tierIndex(tier string) int {
+	tier = strings.ToUpper(tier)
+	for i, t := range tierOrder {
+		if t == tier {
+			return i
+		}
+	}
+	return -1
+}
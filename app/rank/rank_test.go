@@ -0,0 +1,38 @@
+package rank
+
+import "testing"
+
+func TestValueOrdering(t *testing.T) {
+	cases := []struct {
+		name          string
+		loTier, loDiv string
+		loLP          int
+		hiTier, hiDiv string
+		hiLP          int
+	}{
+		{"division beats LP", "GOLD", "II", 99, "GOLD", "I", 0},
+		{"tier beats division", "SILVER", "I", 80, "GOLD", "IV", 0},
+		{"apex stacks LP", "MASTER", "I", 10, "GRANDMASTER", "I", 450},
+		{"unranked sorts last", "", "", 0, "IRON", "IV", 0},
+	}
+	for _, c := range cases {
+		lo := Value(c.loTier, c.loDiv, c.loLP)
+		hi := Value(c.hiTier, c.hiDiv, c.hiLP)
+		if lo >= hi {
+			t.Errorf("%s: Value(%s %s %d)=%d should be below Value(%s %s %d)=%d",
+				c.name, c.loTier, c.loDiv, c.loLP, lo, c.hiTier, c.hiDiv, c.hiLP, hi)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if got := Format("GOLD", "II", 54); got != "GOLD II 54LP" {
+		t.Errorf("Format = %q", got)
+	}
+	if got := Format("MASTER", "I", 120); got != "MASTER 120LP" {
+		t.Errorf("apex Format = %q", got)
+	}
+	if got := Format("", "", 0); got != "ランクなし" {
+		t.Errorf("unranked Format = %q", got)
+	}
+}
@@ -0,0 +1,27 @@
+package rank
+
+import "math"
+
+// Volatility is the standard deviation of day-to-day changes in a
+// series of rank values, i.e. how streaky a climb has been in LP per
+// day. ok is false when fewer than three readings are available.
+func Volatility(dailyValues []int) (sigma float64, ok bool) {
+	if len(dailyValues) < 3 {
+		return 0, false
+	}
+	diffs := make([]float64, 0, len(dailyValues)-1)
+	for i := 1; i < len(dailyValues); i++ {
+		diffs = append(diffs, float64(dailyValues[i]-dailyValues[i-1]))
+	}
+	var mean float64
+	for _, d := range diffs {
+		mean += d
+	}
+	mean /= float64(len(diffs))
+	var variance float64
+	for _, d := range diffs {
+		variance += (d - mean) * (d - mean)
+	}
+	variance /= float64(len(diffs))
+	return math.Sqrt(variance), true
+}
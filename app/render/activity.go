@@ -0,0 +1,80 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"time"
+)
+
+// Activity graph geometry: GitHub-style contribution cells, weeks as
+// columns and weekdays as rows.
+const (
+	activityCell   = 12
+	activityGap    = 3
+	activityMargin = 8
+)
+
+// activityLevels shade cells from "no games" to "busy night", picked to
+// read well on Discord's dark theme.
+var activityLevels = []color.NRGBA{
+	{R: 0x3a, G: 0x3d, B: 0x44, A: 0xff},
+	{R: 0x0e, G: 0x44, B: 0x29, A: 0xff},
+	{R: 0x00, G: 0x6d, B: 0x32, A: 0xff},
+	{R: 0x26, G: 0xa6, B: 0x41, A: 0xff},
+	{R: 0x39, G: 0xd3, B: 0x53, A: 0xff},
+}
+
+// ActivityGraph renders games-per-day counts between from and to
+// (inclusive, JST days) as a PNG contribution graph. Keys of perDay are
+// "YYYY-MM-DD" strings as produced by SQLite's date().
+func ActivityGraph(perDay map[string]int, from, to time.Time) ([]byte, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, JST)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, JST)
+
+	max := 0
+	for _, n := range perDay {
+		if n > max {
+			max = n
+		}
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	weeks := (days + int(from.Weekday()) + 6) / 7
+	width := 2*activityMargin + weeks*activityCell + (weeks-1)*activityGap
+	height := 2*activityMargin + 7*activityCell + 6*activityGap
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for d := 0; d < days; d++ {
+		day := from.AddDate(0, 0, d)
+		col := (d + int(from.Weekday())) / 7
+		row := int(day.Weekday())
+		level := activityLevel(perDay[day.Format("2006-01-02")], max)
+		x0 := activityMargin + col*(activityCell+activityGap)
+		y0 := activityMargin + row*(activityCell+activityGap)
+		for y := y0; y < y0+activityCell; y++ {
+			for x := x0; x < x0+activityCell; x++ {
+				img.SetNRGBA(x, y, activityLevels[level])
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// activityLevel buckets a day's count into a shade index.
+func activityLevel(count, max int) int {
+	if count == 0 || max == 0 {
+		return 0
+	}
+	level := 1 + (count-1)*(len(activityLevels)-2)/max
+	if level > len(activityLevels)-1 {
+		level = len(activityLevels) - 1
+	}
+	return level
+}
@@ -0,0 +1,24 @@
+package render
+
+import (
+	"strings"
+	"time"
+)
+
+// FreshnessFooter renders the standard staleness footer for
+// data-bearing messages: one segment per data source with the time it
+// was last fetched, so readers can judge how current the numbers are.
+// Zero times are skipped; with no known source the footer is empty.
+func FreshnessFooter(leagueAt, matchAt time.Time) string {
+	var parts []string
+	if !leagueAt.IsZero() {
+		parts = append(parts, "ランク情報 "+leagueAt.In(JST).Format("01/02 15:04"))
+	}
+	if !matchAt.IsZero() {
+		parts = append(parts, "試合情報 "+matchAt.In(JST).Format("01/02 15:04"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "🕒 " + strings.Join(parts, " ／ ") + " 取得"
+}
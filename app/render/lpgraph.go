@@ -0,0 +1,72 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Line graph geometry: one column per data point, sized to stay
+// readable inline on Discord.
+const (
+	lineStep   = 8
+	lineHeight = 160
+	lineMargin = 12
+)
+
+var (
+	lineColor = color.NRGBA{R: 0x39, G: 0xd3, B: 0x53, A: 0xff}
+	gridColor = color.NRGBA{R: 0x3a, G: 0x3d, B: 0x44, A: 0xff}
+)
+
+// LineGraph renders a value series, oldest first, as a PNG polyline.
+// The vertical axis spans the series' own min..max so small movements
+// stay visible even when the absolute values are large.
+func LineGraph(values []int) ([]byte, error) {
+	if len(values) == 1 {
+		// A single reading still draws as a flat line.
+		values = append(values, values[0])
+	}
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	span := max - min
+	if span == 0 {
+		span = 1
+	}
+
+	width := 2*lineMargin + (len(values)-1)*lineStep
+	height := 2*lineMargin + lineHeight
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for q := 0; q <= 4; q++ {
+		y := lineMargin + q*lineHeight/4
+		for x := lineMargin; x <= width-lineMargin; x++ {
+			img.SetNRGBA(x, y, gridColor)
+		}
+	}
+
+	yFor := func(v int) int { return lineMargin + lineHeight - (v-min)*lineHeight/span }
+	for i := 0; i < len(values)-1; i++ {
+		x0 := lineMargin + i*lineStep
+		y0, y1 := yFor(values[i]), yFor(values[i+1])
+		for dx := 0; dx <= lineStep; dx++ {
+			y := y0 + (y1-y0)*dx/lineStep
+			img.SetNRGBA(x0+dx, y, lineColor)
+			img.SetNRGBA(x0+dx, y+1, lineColor)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
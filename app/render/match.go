@@ -0,0 +1,57 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/i18n"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// MatchReport renders the full scoreboard of one game. PUUIDs present
+// in highlight are marked as registered players.
+func MatchReport(m *riotapi.MatchDTO, highlight map[string]bool, loc i18n.Locale) string {
+	var b strings.Builder
+	end := time.UnixMilli(m.Info.GameEndTimestamp).In(JST)
+	fmt.Fprintf(&b, "**%s** (%s, %s)\n",
+		m.Metadata.MatchID, loc.DateTime(end), loc.Duration(time.Duration(m.Info.GameDuration)*time.Second))
+
+	teams := []struct {
+		id    int
+		label string
+	}{
+		{100, "ブルーサイド"},
+		{200, "レッドサイド"},
+	}
+	for _, team := range teams {
+		result := ""
+		for _, p := range m.Info.Participants {
+			if p.TeamID == team.id {
+				if p.Win {
+					result = "勝利"
+				} else {
+					result = "敗北"
+				}
+				break
+			}
+		}
+		fmt.Fprintf(&b, "**%s** — %s\n```\n", team.label, result)
+		for _, p := range m.Info.Participants {
+			if p.TeamID != team.id {
+				continue
+			}
+			mark := " "
+			if highlight[p.PUUID] {
+				mark = "★"
+			}
+			fmt.Fprintf(&b, "%s %-14s %-20s %2d/%2d/%2d  %5.1fk dmg  %4.1fk gold\n",
+				mark, p.ChampionName, p.RiotID(),
+				p.Kills, p.Deaths, p.Assists,
+				float64(p.TotalDamageDealtToChampions)/1000,
+				float64(p.GoldEarned)/1000)
+		}
+		b.WriteString("```\n")
+	}
+	return b.String()
+}
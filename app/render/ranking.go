@@ -0,0 +1,112 @@
+// Package render builds the Discord message bodies shared by the
+// gateway's commands and the worker's scheduled posts.
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/i18n"
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// JST is the display timezone for all user-facing timestamps.
+var JST = time.FixedZone("JST", 9*60*60)
+
+// Format selects how much detail data-bearing messages carry.
+type Format int
+
+const (
+	// Detailed is the full multi-line rendering.
+	Detailed Format = iota
+	// Compact is a terse rendering for busy channels.
+	Compact
+)
+
+// ParseFormat resolves a stored or typed format name.
+func ParseFormat(s string) (Format, bool) {
+	switch s {
+	case "compact":
+		return Compact, true
+	case "detailed", "":
+		return Detailed, true
+	default:
+		return Detailed, false
+	}
+}
+
+// Standard leaderboard titles; callers with a filtered or per-queue
+// view pass their own.
+const (
+	SoloRankingTitle = "ソロランク ランキング"
+	FlexRankingTitle = "フレックス ランキング"
+)
+
+// Ranking renders the guild leaderboard from the latest snapshots.
+// title heads the Detailed rendering; the Compact one stays terse and
+// unlabeled.
+func Ranking(players []storage.RankedPlayer, title string, format Format, loc i18n.Locale) string {
+	if len(players) == 0 {
+		return "登録されているプレイヤーがいません。`!add <ゲーム名#タグ>` で登録してください。"
+	}
+	now := time.Now()
+	sorted := make([]storage.RankedPlayer, len(players))
+	copy(sorted, players)
+	// Paused players sink to the bottom regardless of rank.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, pj := sorted[i].IsPaused(now), sorted[j].IsPaused(now)
+		if pi != pj {
+			return !pi
+		}
+		return rank.Value(sorted[i].Tier, sorted[i].Division, sorted[i].LP) >
+			rank.Value(sorted[j].Tier, sorted[j].Division, sorted[j].LP)
+	})
+
+	if format == Compact {
+		parts := make([]string, 0, len(sorted))
+		pos := 0
+		for _, p := range sorted {
+			if p.IsPaused(now) {
+				parts = append(parts, fmt.Sprintf("💤 %s", p.GameName))
+				continue
+			}
+			pos++
+			parts = append(parts, fmt.Sprintf("%d. %s (%s)", pos, p.GameName, rank.Short(p.Tier, p.Division, p.LP)))
+		}
+		return "🏆 " + strings.Join(parts, " | ")
+	}
+
+	var b strings.Builder
+	b.WriteString("**" + title + "**\n```\n")
+	var newest time.Time
+	pos := 0
+	for _, p := range sorted {
+		if p.IsPaused(now) {
+			b.WriteString(fmt.Sprintf("--. %-24s %s (休止中)\n", p.RiotID(), rank.Format(p.Tier, p.Division, p.LP)))
+			continue
+		}
+		pos++
+		line := fmt.Sprintf("%2d. %-24s %s", pos, p.RiotID(), rank.Format(p.Tier, p.Division, p.LP))
+		if games := p.Wins + p.Losses; games > 0 {
+			line += fmt.Sprintf(" 勝率%.0f%% (%d戦)", float64(p.Wins)/float64(games)*100, games)
+		}
+		if p.LPDeltaKnown && p.LPDelta != 0 {
+			line += fmt.Sprintf(" 今日%+dLP", p.LPDelta)
+		}
+		if p.Trend != "" {
+			line += " " + p.Trend
+		}
+		b.WriteString(line + "\n")
+		if p.TakenAt.After(newest) {
+			newest = p.TakenAt
+		}
+	}
+	b.WriteString("```")
+	if !newest.IsZero() {
+		b.WriteString("\n" + loc.AsOf(newest.In(JST)))
+	}
+	return b.String()
+}
@@ -0,0 +1,217 @@
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/i18n"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// DailyRecap renders the previous day's results. Games several
+// registered players shared are grouped into a stack section instead of
+// being repeated under each player. mention maps a linked Discord user
+// ID to a mention token ("" to skip); nil disables mentions entirely.
+func DailyRecap(day time.Time, entries []storage.PlayerMatches, loc i18n.Locale, showVision bool, mention func(discordUserID string) string) string {
+	type shared struct {
+		match   riotapi.MatchDTO
+		players []string
+		wins    int
+		losses  int
+	}
+	sharedByID := map[string]*shared{}
+	seen := map[string]int{}
+	for _, e := range entries {
+		for _, m := range e.Matches {
+			seen[m.Metadata.MatchID]++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s の戦績まとめ**\n", loc.Date(day))
+
+	played := false
+	for _, e := range entries {
+		wins, losses := 0, 0
+		for _, m := range e.Matches {
+			won := participantWin(&m, e.Player.PUUID)
+			if seen[m.Metadata.MatchID] > 1 {
+				s := sharedByID[m.Metadata.MatchID]
+				if s == nil {
+					s = &shared{match: m}
+					sharedByID[m.Metadata.MatchID] = s
+				}
+				s.players = append(s.players, e.Player.GameName)
+				if won {
+					s.wins++
+				} else {
+					s.losses++
+				}
+				continue
+			}
+			if won {
+				wins++
+			} else {
+				losses++
+			}
+		}
+		if wins+losses > 0 {
+			name := e.Player.RiotID()
+			// Ping the linked user so the shoutout reaches them, unless
+			// the caller (or their preference) says otherwise.
+			if mention != nil {
+				if m := mention(e.Player.DiscordUserID); m != "" {
+					name += " (" + m + ")"
+				}
+			}
+			fmt.Fprintf(&b, "・%s: %s\n", name, loc.WinLoss(wins, losses))
+			played = true
+		}
+	}
+
+	if len(sharedByID) > 0 {
+		b.WriteString("\n**一緒に戦った試合**\n")
+		ids := make([]string, 0, len(sharedByID))
+		for id := range sharedByID {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			s := sharedByID[id]
+			switch {
+			case s.losses == 0:
+				fmt.Fprintf(&b, "・%d人スタックで勝利！ (%s)\n", len(s.players), strings.Join(s.players, ", "))
+			case s.wins == 0:
+				fmt.Fprintf(&b, "・%d人スタックで敗北… (%s)\n", len(s.players), strings.Join(s.players, ", "))
+			default:
+				// Registered players ended up on opposite teams.
+				fmt.Fprintf(&b, "・仲間内対決！ (%s)\n", strings.Join(s.players, ", "))
+			}
+		}
+		played = true
+	}
+
+	if played {
+		var lines []string
+		if line := bestGameLine(entries); line != "" {
+			lines = append(lines, line)
+		}
+		if showVision {
+			if line := visionLine(entries); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			b.WriteString("\n" + strings.Join(lines, "\n") + "\n")
+		}
+	}
+
+	if !played {
+		b.WriteString("この日の対戦はありませんでした。\n")
+	}
+	return b.String()
+}
+
+// bestGameLine highlights the day's single best performance among
+// registered players: highest KDA, damage share breaking ties. The
+// match ID is included so the game can be pulled up with the match
+// command.
+func bestGameLine(entries []storage.PlayerMatches) string {
+	type perf struct {
+		name, champion, matchID string
+		kills, deaths, assists  int
+		kda, damageShare        float64
+	}
+	var best *perf
+	for _, e := range entries {
+		for _, m := range e.Matches {
+			teamDamage := map[int]int{}
+			for _, p := range m.Info.Participants {
+				teamDamage[p.TeamID] += p.TotalDamageDealtToChampions
+			}
+			for _, p := range m.Info.Participants {
+				if p.PUUID != e.Player.PUUID {
+					continue
+				}
+				// A deathless game divides by one so it still ranks.
+				deaths := p.Deaths
+				if deaths == 0 {
+					deaths = 1
+				}
+				r := &perf{
+					name:     e.Player.GameName,
+					champion: p.ChampionName,
+					matchID:  m.Metadata.MatchID,
+					kills:    p.Kills,
+					deaths:   p.Deaths,
+					assists:  p.Assists,
+					kda:      float64(p.Kills+p.Assists) / float64(deaths),
+				}
+				if td := teamDamage[p.TeamID]; td > 0 {
+					r.damageShare = float64(p.TotalDamageDealtToChampions) / float64(td) * 100
+				}
+				if best == nil || r.kda > best.kda ||
+					(r.kda == best.kda && r.damageShare > best.damageShare) {
+					best = r
+				}
+				break
+			}
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return fmt.Sprintf("🏆 ベストゲーム: %s の %s %d/%d/%d (KDA %.2f・ダメージ占有率 %.0f%%) — 試合ID `%s`",
+		best.name, best.champion, best.kills, best.deaths, best.assists, best.kda, best.damageShare, best.matchID)
+}
+
+// visionLine picks the day's best and worst vision score per minute
+// among registered players' games.
+func visionLine(entries []storage.PlayerMatches) string {
+	type reading struct {
+		name   string
+		perMin float64
+	}
+	var best, worst *reading
+	for _, e := range entries {
+		for _, m := range e.Matches {
+			if m.Info.GameDuration <= 0 {
+				continue
+			}
+			for _, p := range m.Info.Participants {
+				if p.PUUID != e.Player.PUUID {
+					continue
+				}
+				r := &reading{
+					name:   e.Player.GameName,
+					perMin: float64(p.VisionScore) / (float64(m.Info.GameDuration) / 60),
+				}
+				if best == nil || r.perMin > best.perMin {
+					best = r
+				}
+				if worst == nil || r.perMin < worst.perMin {
+					worst = r
+				}
+				break
+			}
+		}
+	}
+	if best == nil || best == worst || best.name == worst.name {
+		return ""
+	}
+	return fmt.Sprintf("👁 視界スコア: 最高 %s (%.2f/分) ／ 要反省 %s (%.2f/分)",
+		best.name, best.perMin, worst.name, worst.perMin)
+}
+
+// participantWin reports whether the given PUUID won the match.
+func participantWin(m *riotapi.MatchDTO, puuid string) bool {
+	for _, p := range m.Info.Participants {
+		if p.PUUID == puuid {
+			return p.Win
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package render
+
+import "strings"
+
+// Discord's hard size limits, shared so every sender chunks and clamps
+// the same way.
+const (
+	// MessageLimit is the message content length cap.
+	MessageLimit = 2000
+	// EmbedFieldLimit is the embed field value length cap.
+	EmbedFieldLimit = 1024
+)
+
+// SplitMessage splits content into Discord-sized chunks at line
+// boundaries — never mid-line, so markdown links and table rows stay
+// intact — closing and re-opening an open code fence across the split
+// so preformatted tables stay readable.
+func SplitMessage(content string) []string {
+	if len(content) <= MessageLimit {
+		return []string{content}
+	}
+	var chunks []string
+	var b strings.Builder
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		// Reserve room for the newline and a closing fence.
+		if b.Len() > 0 && b.Len()+len(line)+5 > MessageLimit {
+			chunk := b.String()
+			if inFence {
+				chunk += "\n```"
+			}
+			chunks = append(chunks, chunk)
+			b.Reset()
+			if inFence {
+				b.WriteString("```\n")
+			}
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(line)
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+	}
+	if b.Len() > 0 {
+		chunks = append(chunks, b.String())
+	}
+	return chunks
+}
+
+// ClampField trims an embed field value to Discord's limit, marking the
+// cut with an ellipsis.
+func ClampField(value string) string {
+	if len(value) <= EmbedFieldLimit {
+		return value
+	}
+	cut := EmbedFieldLimit - len("…")
+	// Back up to a rune boundary so multi-byte text is not split.
+	for cut > 0 && value[cut]&0xC0 == 0x80 {
+		cut--
+	}
+	return value[:cut] + "…"
+}
@@ -0,0 +1,57 @@
+package riotapi
+
+import (
+	"sync"
+	"time"
+)
+
+// lookupTTL bounds how long identity lookups are reused. Riot ID →
+// PUUID → summoner mappings only change on renames and transfers, so a
+// day is conservative; lookupCacheMax caps memory on busy instances.
+const (
+	lookupTTL      = 24 * time.Hour
+	lookupCacheMax = 4096
+)
+
+// lookupCache memoizes the identity lookups that practically never
+// change, so repeated commands only spend API budget on the league
+// entries call. It is shared by every client derived from the same New,
+// across platforms and keys: identity data doesn't depend on either.
+type lookupCache struct {
+	mu sync.Mutex
+	m  map[string]lookupEntry
+}
+
+type lookupEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{m: map[string]lookupEntry{}}
+}
+
+func (lc *lookupCache) get(key string) (interface{}, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	e, ok := lc.m[key]
+	if !ok || time.Now().After(e.expires) {
+		delete(lc.m, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (lc *lookupCache) put(key string, v interface{}) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if len(lc.m) >= lookupCacheMax {
+		now := time.Now()
+		for k, e := range lc.m {
+			if now.After(e.expires) {
+				delete(lc.m, k)
+			}
+		}
+	}
+	lc.m[key] = lookupEntry{value: v, expires: time.Now().Add(lookupTTL)}
+}
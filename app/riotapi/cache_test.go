@@ -0,0 +1,27 @@
+package riotapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookupCache(t *testing.T) {
+	lc := newLookupCache()
+	if _, ok := lc.get("account:a#b"); ok {
+		t.Fatal("empty cache reported a hit")
+	}
+	lc.put("account:a#b", &AccountDTO{PUUID: "p"})
+	v, ok := lc.get("account:a#b")
+	if !ok || v.(*AccountDTO).PUUID != "p" {
+		t.Fatalf("get = %v, %v", v, ok)
+	}
+}
+
+func TestLookupCacheExpiry(t *testing.T) {
+	lc := newLookupCache()
+	lc.put("k", "v")
+	lc.m["k"] = lookupEntry{value: "v", expires: time.Now().Add(-time.Second)}
+	if _, ok := lc.get("k"); ok {
+		t.Fatal("expired entry reported a hit")
+	}
+}
@@ -0,0 +1,284 @@
+// Package riotapi is a small client for the Riot Games API covering the
+// endpoints the bot needs: account-v1, summoner-v4, league-v4,
+// spectator-v5 and match-v5.
+package riotapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client talks to the Riot API for a single platform/region pair.
+type Client struct {
+	apiKey      string
+	platformURL string // https://jp1.api.riotgames.com
+	regionalURL string // https://asia.api.riotgames.com
+	httpClient  *http.Client
+	limiter     *limiter
+	lookups     *lookupCache
+	// regional, when set, routes regional calls through failover
+	// between the primary and the fallback routing values.
+	regional  *failover
+	fallbacks []string
+}
+
+// New builds a Client for the given platform (jp1, kr, ...) and
+// regional routing value (asia, americas, europe).
+func New(apiKey, platform, region string) *Client {
+	return &Client{
+		apiKey:      apiKey,
+		platformURL: fmt.Sprintf("https://%s.api.riotgames.com", platform),
+		regionalURL: fmt.Sprintf("https://%s.api.riotgames.com", region),
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		limiter:     &limiter{},
+		lookups:     newLookupCache(),
+	}
+}
+
+// NewWithBaseURLs builds a client against explicit platform and
+// regional base URLs. Harnesses (the loadtest command, integration
+// fixtures) use it to point the real client — limiter, retries and all
+// — at a local fake Riot server.
+func NewWithBaseURLs(apiKey, platformURL, regionalURL string) *Client {
+	return &Client{
+		apiKey:      apiKey,
+		platformURL: platformURL,
+		regionalURL: regionalURL,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		limiter:     &limiter{},
+		lookups:     newLookupCache(),
+	}
+}
+
+// WithAPIKey returns a client using a different API key, with its own
+// rate limiter: a separate key is a separate request budget.
+func (c *Client) WithAPIKey(apiKey string) *Client {
+	clone := *c
+	clone.apiKey = apiKey
+	clone.limiter = &limiter{}
+	return &clone
+}
+
+// WithRegionFallbacks returns a client that fails over to the given
+// alternate regional routing values ("sea", "americas", ...) when the
+// primary regional host is degraded. onSwitch, when non-nil, is called
+// on every switch so the caller can alert the operator.
+func (c *Client) WithRegionFallbacks(fallbacks []string, onSwitch func(from, to string)) *Client {
+	clone := *c
+	clone.fallbacks = fallbacks
+	clone.regional = newFailover(c.regionalURL, fallbacks, onSwitch)
+	return &clone
+}
+
+// ForPlatform returns a client routed to another platform (and its
+// matching region), sharing this client's API key, HTTP transport and
+// rate limiter. Failover state is shared while the region stays the
+// same and rebuilt for the new primary otherwise.
+func (c *Client) ForPlatform(platform string) *Client {
+	clone := *c
+	clone.platformURL = fmt.Sprintf("https://%s.api.riotgames.com", platform)
+	regionalURL := fmt.Sprintf("https://%s.api.riotgames.com", Region(platform))
+	if regionalURL != c.regionalURL {
+		clone.regionalURL = regionalURL
+		if c.regional != nil {
+			clone.regional = newFailover(regionalURL, c.fallbacks, c.regional.onSwitch)
+		}
+	}
+	return &clone
+}
+
+// AccountByRiotID resolves a gameName#tagLine pair to an account.
+// Results are cached: the mapping only changes on a rename.
+func (c *Client) AccountByRiotID(gameName, tagLine string) (*AccountDTO, error) {
+	key := "account:" + gameName + "#" + tagLine
+	if v, ok := c.lookups.get(key); ok {
+		return v.(*AccountDTO), nil
+	}
+	path := fmt.Sprintf("/riot/account/v1/accounts/by-riot-id/%s/%s",
+		url.PathEscape(gameName), url.PathEscape(tagLine))
+	var out AccountDTO
+	if err := c.getRegional("account-by-riot-id", path, &out); err != nil {
+		return nil, err
+	}
+	if err := out.validate(); err != nil {
+		return nil, err
+	}
+	c.lookups.put(key, &out)
+	return &out, nil
+}
+
+// ActivePlatform resolves which platform a PUUID actively plays LoL on
+// (e.g. "jp1", "kr") via the account-v1 region endpoint.
+func (c *Client) ActivePlatform(puuid string) (string, error) {
+	var out ActiveShardDTO
+	if err := c.getRegional("active-shard", "/riot/account/v1/region/by-game/lol/by-puuid/"+url.PathEscape(puuid), &out); err != nil {
+		return "", err
+	}
+	return strings.ToLower(out.Region), nil
+}
+
+// SummonerByPUUID fetches the summoner record behind a PUUID. Results
+// are cached per platform: the mapping only changes on a transfer.
+func (c *Client) SummonerByPUUID(puuid string) (*SummonerDTO, error) {
+	key := "summoner:" + c.platformURL + ":" + puuid
+	if v, ok := c.lookups.get(key); ok {
+		return v.(*SummonerDTO), nil
+	}
+	var out SummonerDTO
+	if err := c.get("summoner-by-puuid", c.platformURL+"/lol/summoner/v4/summoners/by-puuid/"+url.PathEscape(puuid), &out); err != nil {
+		return nil, err
+	}
+	if err := out.validate(); err != nil {
+		return nil, err
+	}
+	c.lookups.put(key, &out)
+	return &out, nil
+}
+
+// LeagueEntriesBySummonerID returns all ranked queue entries for a
+// summoner. Entries that fail validation are dropped with a log line
+// rather than failing the whole lookup: the other queues' data is
+// still good.
+func (c *Client) LeagueEntriesBySummonerID(summonerID string) ([]LeagueEntryDTO, error) {
+	var out []LeagueEntryDTO
+	if err := c.get("league-entries", c.platformURL+"/lol/league/v4/entries/by-summoner/"+url.PathEscape(summonerID), &out); err != nil {
+		return nil, err
+	}
+	valid := out[:0]
+	for i := range out {
+		if err := out[i].validate(); err != nil {
+			log.Printf("riotapi: dropping league entry for %s: %v", summonerID, err)
+			continue
+		}
+		valid = append(valid, out[i])
+	}
+	return valid, nil
+}
+
+// ActiveGameByPUUID fetches the live game a PUUID is currently in;
+// ErrNotFound means they are not in a game.
+func (c *Client) ActiveGameByPUUID(puuid string) (*CurrentGameInfoDTO, error) {
+	var out CurrentGameInfoDTO
+	if err := c.get("active-game", c.platformURL+"/lol/spectator/v5/active-games/by-summoner/"+url.PathEscape(puuid), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ChampionMasteriesTopByPUUID returns a player's highest-mastery
+// champions, at most count of them.
+func (c *Client) ChampionMasteriesTopByPUUID(puuid string, count int) ([]ChampionMasteryDTO, error) {
+	var out []ChampionMasteryDTO
+	path := c.platformURL + "/lol/champion-mastery/v4/champion-masteries/by-puuid/" +
+		url.PathEscape(puuid) + "/top?count=" + strconv.Itoa(count)
+	if err := c.get("champion-mastery", path, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MatchIDsByPUUID lists match IDs for a PUUID between startTime and endTime
+// (unix seconds; pass zero to leave either end open), newest first.
+func (c *Client) MatchIDsByPUUID(puuid string, startTime, endTime int64, count int) ([]string, error) {
+	q := url.Values{}
+	if startTime > 0 {
+		q.Set("startTime", strconv.FormatInt(startTime, 10))
+	}
+	if endTime > 0 {
+		q.Set("endTime", strconv.FormatInt(endTime, 10))
+	}
+	q.Set("count", strconv.Itoa(count))
+	var out []string
+	path := "/lol/match/v5/matches/by-puuid/" + url.PathEscape(puuid) + "/ids?" + q.Encode()
+	if err := c.getRegional("match-ids", path, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Match fetches a single match by its ID.
+func (c *Client) Match(matchID string) (*MatchDTO, error) {
+	var out MatchDTO
+	if err := c.getRegional("match", "/lol/match/v5/matches/"+url.PathEscape(matchID), &out); err != nil {
+		return nil, err
+	}
+	if err := out.validate(); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// getRegional performs get against the currently healthy regional
+// host, feeding the outcome back into the failover tracker.
+func (c *Client) getRegional(method, path string, v interface{}) error {
+	if c.regional == nil {
+		return c.get(method, c.regionalURL+path, v)
+	}
+	host := c.regional.pick()
+	err := c.get(method, host+path, v)
+	c.regional.report(host, err)
+	return err
+}
+
+// retryAttempts bounds how many times a transient failure is retried
+// before the error reaches the caller; retryBaseDelay seeds the
+// exponential backoff between attempts.
+const (
+	retryAttempts  = 3
+	retryBaseDelay = 500 * time.Millisecond
+)
+
+// get performs a rate-limited GET and decodes the JSON body into v.
+// method names the endpoint for per-method pacing; every helper passes
+// a distinct one. Transient failures — 429s, 5xx and transport errors —
+// are retried with exponential backoff so a single hiccup doesn't drop
+// a player from the output; a 429's Retry-After additionally blocks the
+// limiter so the retry (and everyone else) waits it out.
+func (c *Client) get(method, rawURL string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Riot-Token", c.apiKey)
+	var lastErr error
+	for attempt := 0; attempt < retryAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryBaseDelay << (attempt - 1))
+		}
+		c.limiter.wait(req.URL.Host, method)
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		c.limiter.observe(req.URL.Host, method, resp.Header)
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return json.Unmarshal(body, v)
+		case resp.StatusCode == http.StatusTooManyRequests:
+			if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+				c.limiter.block(req.URL.Host, method, time.Duration(secs)*time.Second)
+			}
+			lastErr = statusError(req.URL.Path, resp.StatusCode)
+		case resp.StatusCode >= 500:
+			lastErr = statusError(req.URL.Path, resp.StatusCode)
+		default:
+			// 4xx other than 429 won't change on retry.
+			return statusError(req.URL.Path, resp.StatusCode)
+		}
+	}
+	return lastErr
+}
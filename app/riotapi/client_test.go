@@ -0,0 +1,77 @@
+package riotapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// testClient points a Client at a test server, bypassing New's URL
+// construction.
+func testClient(srv *httptest.Server) *Client {
+	return &Client{
+		apiKey:      "test-key",
+		platformURL: srv.URL,
+		regionalURL: srv.URL,
+		httpClient:  srv.Client(),
+		limiter:     &limiter{},
+		lookups:     newLookupCache(),
+	}
+}
+
+func TestClientGetDecodesAndSendsKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Riot-Token"); got != "test-key" {
+			t.Errorf("X-Riot-Token = %q", got)
+		}
+		w.Write([]byte(`{"puuid":"p","gameName":"a","tagLine":"b"}`))
+	}))
+	defer srv.Close()
+
+	account, err := testClient(srv).AccountByRiotID("a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.PUUID != "p" {
+		t.Fatalf("PUUID = %q", account.PUUID)
+	}
+}
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Generous limits keep the limiter from slowing the retry down.
+		w.Header().Set("X-App-Rate-Limit", "1000:1")
+		w.Header().Set("X-App-Rate-Limit-Count", "1:1")
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	if _, err := testClient(srv).LeagueEntriesBySummonerID("s"); err != nil {
+		t.Fatalf("retry did not recover: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("calls = %d, want 2", calls.Load())
+	}
+}
+
+func TestClientDoesNotRetryNotFound(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	if _, err := testClient(srv).SummonerByPUUID("p"); err == nil {
+		t.Fatal("want error for 404")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("calls = %d, want 1", calls.Load())
+	}
+}
@@ -0,0 +1,38 @@
+package riotapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for the Riot API responses handlers care about.
+// Check them with errors.Is; the wrapped error keeps the endpoint and
+// status code for logging.
+var (
+	// ErrNotFound means the requested resource does not exist
+	// (unknown Riot ID, no such match, ...).
+	ErrNotFound = errors.New("riot api: not found")
+	// ErrRateLimited means the API key's rate limit was exceeded.
+	ErrRateLimited = errors.New("riot api: rate limited")
+	// ErrForbidden usually means the API key is invalid or expired.
+	ErrForbidden = errors.New("riot api: forbidden")
+	// ErrUnavailable covers Riot-side outages (5xx responses).
+	ErrUnavailable = errors.New("riot api: unavailable")
+)
+
+// statusError maps an HTTP status to the matching sentinel, or builds a
+// one-off error for statuses without one.
+func statusError(path string, status int) error {
+	switch {
+	case status == 404:
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	case status == 429:
+		return fmt.Errorf("%w: %s", ErrRateLimited, path)
+	case status == 401 || status == 403:
+		return fmt.Errorf("%w: %s returned %d", ErrForbidden, path, status)
+	case status >= 500:
+		return fmt.Errorf("%w: %s returned %d", ErrUnavailable, path, status)
+	default:
+		return fmt.Errorf("riot api: %s returned %d", path, status)
+	}
+}
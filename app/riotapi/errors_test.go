@@ -0,0 +1,30 @@
+package riotapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStatusError(t *testing.T) {
+	cases := []struct {
+		status int
+		want   error
+	}{
+		{404, ErrNotFound},
+		{429, ErrRateLimited},
+		{401, ErrForbidden},
+		{403, ErrForbidden},
+		{500, ErrUnavailable},
+		{503, ErrUnavailable},
+	}
+	for _, c := range cases {
+		if err := statusError("/lol/test", c.status); !errors.Is(err, c.want) {
+			t.Errorf("statusError(%d) = %v, want %v", c.status, err, c.want)
+		}
+	}
+	if err := statusError("/lol/test", 418); err == nil ||
+		errors.Is(err, ErrNotFound) || errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, ErrForbidden) || errors.Is(err, ErrUnavailable) {
+		t.Errorf("statusError(418) = %v, want plain error", err)
+	}
+}
@@ -0,0 +1,96 @@
+package riotapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// failoverThreshold consecutive regional failures trigger a switch to
+// the next host; failoverRetryPrimary is how long traffic stays on a
+// fallback before the primary is probed again.
+const (
+	failoverThreshold    = 3
+	failoverRetryPrimary = 10 * time.Minute
+)
+
+// failover tracks the health of the regional routing hosts and decides
+// which one requests go to. Platform hosts are not covered: an incident
+// there affects one shard's players anyway, while the regional host
+// carries every account and match-v5 call.
+type failover struct {
+	mu         sync.Mutex
+	hosts      []string // URLs, primary first
+	current    int
+	failures   int
+	switchedAt time.Time
+	// onSwitch is called (outside the lock's critical path, but
+	// serialized) whenever traffic moves between hosts, so the caller
+	// can alert the operator.
+	onSwitch func(from, to string)
+}
+
+func newFailover(primary string, fallbacks []string, onSwitch func(from, to string)) *failover {
+	hosts := []string{primary}
+	for _, region := range fallbacks {
+		hosts = append(hosts, fmt.Sprintf("https://%s.api.riotgames.com", region))
+	}
+	return &failover{hosts: hosts, onSwitch: onSwitch}
+}
+
+// pick returns the host the next request should use. After
+// failoverRetryPrimary on a fallback it routes live traffic back to the
+// primary as the health probe; if the primary is still down, the
+// failure threshold flips traffic away again.
+func (f *failover) pick() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.current != 0 && time.Since(f.switchedAt) >= failoverRetryPrimary {
+		f.swap(0)
+	}
+	return f.hosts[f.current]
+}
+
+// report feeds one request's outcome back. Only host-level trouble
+// counts against a host; results for a host traffic already left are
+// ignored.
+func (f *failover) report(host string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.hosts[f.current] != host {
+		return
+	}
+	if !regionalFailure(err) {
+		f.failures = 0
+		return
+	}
+	f.failures++
+	if f.failures >= failoverThreshold {
+		f.swap((f.current + 1) % len(f.hosts))
+	}
+}
+
+func (f *failover) swap(next int) {
+	from, to := f.hosts[f.current], f.hosts[next]
+	f.current, f.failures, f.switchedAt = next, 0, time.Now()
+	if f.onSwitch != nil && from != to {
+		f.onSwitch(from, to)
+	}
+}
+
+// regionalFailure reports whether err suggests the routing host itself
+// is degraded rather than the request being wrong: a Riot-side 5xx that
+// survived the retries, or a transport-level failure (DNS, TLS,
+// timeout). Quota and client errors don't count.
+func regionalFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrUnavailable) {
+		return true
+	}
+	var uerr *url.Error
+	return errors.As(err, &uerr)
+}
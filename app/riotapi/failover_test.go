@@ -0,0 +1,61 @@
+package riotapi
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFailoverSwitchesAfterThreshold(t *testing.T) {
+	var switched []string
+	f := newFailover("https://asia.api.riotgames.com", []string{"sea"}, func(from, to string) {
+		switched = append(switched, fmt.Sprintf("%s->%s", from, to))
+	})
+	primary := f.pick()
+	for i := 0; i < failoverThreshold; i++ {
+		f.report(primary, fmt.Errorf("%w: down", ErrUnavailable))
+	}
+	if got := f.pick(); got != "https://sea.api.riotgames.com" {
+		t.Fatalf("pick after threshold = %s", got)
+	}
+	if len(switched) != 1 {
+		t.Fatalf("onSwitch calls = %d, want 1", len(switched))
+	}
+}
+
+func TestFailoverSuccessResetsCount(t *testing.T) {
+	f := newFailover("https://asia.api.riotgames.com", []string{"sea"}, nil)
+	primary := f.pick()
+	for i := 0; i < failoverThreshold-1; i++ {
+		f.report(primary, fmt.Errorf("%w: down", ErrUnavailable))
+	}
+	f.report(primary, nil)
+	f.report(primary, fmt.Errorf("%w: down", ErrUnavailable))
+	if got := f.pick(); got != primary {
+		t.Fatalf("switched away despite reset: %s", got)
+	}
+}
+
+func TestFailoverProbesPrimaryAgain(t *testing.T) {
+	f := newFailover("https://asia.api.riotgames.com", []string{"sea"}, nil)
+	primary := f.pick()
+	for i := 0; i < failoverThreshold; i++ {
+		f.report(primary, fmt.Errorf("%w: down", ErrUnavailable))
+	}
+	f.switchedAt = time.Now().Add(-failoverRetryPrimary - time.Second)
+	if got := f.pick(); got != primary {
+		t.Fatalf("did not probe primary after interval: %s", got)
+	}
+}
+
+func TestRegionalFailureClassification(t *testing.T) {
+	if regionalFailure(fmt.Errorf("%w: x", ErrNotFound)) {
+		t.Fatal("404 counted as host failure")
+	}
+	if regionalFailure(fmt.Errorf("%w: x", ErrRateLimited)) {
+		t.Fatal("429 counted as host failure")
+	}
+	if !regionalFailure(fmt.Errorf("%w: x returned 503", ErrUnavailable)) {
+		t.Fatal("503 not counted as host failure")
+	}
+}
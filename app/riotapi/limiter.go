@@ -0,0 +1,153 @@
+package riotapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minInterval is the request spacing used until the first response's
+// rate-limit headers have been observed. It matches a development key
+// (20 req / 1 s, 100 req / 2 min) so the cold start can never trip a
+// 429; production keys speed up as soon as headers arrive.
+const minInterval = 1200 * time.Millisecond
+
+// limiter paces requests per routing value (host) and per API method,
+// adapting to the X-App-Rate-Limit / X-Method-Rate-Limit headers Riot
+// returns. Clients derived with ForPlatform share it so all routes
+// count against the same key.
+type limiter struct {
+	mu     sync.Mutex
+	app    map[string]*bucket // keyed by host
+	method map[string]*bucket // keyed by host + " " + method
+}
+
+// bucket is the pacing state for one rate-limit scope.
+type bucket struct {
+	lastCall time.Time
+	// interval is the adaptive spacing derived from the tightest
+	// advertised window; zero means headers have not been seen yet and
+	// minInterval applies.
+	interval time.Duration
+	// blockedUntil delays the next request after a window was exhausted
+	// or the API answered 429.
+	blockedUntil time.Time
+}
+
+// next is the earliest time this bucket allows another request.
+func (b *bucket) next() time.Time {
+	interval := b.interval
+	if interval == 0 {
+		interval = minInterval
+	}
+	at := b.lastCall.Add(interval)
+	if b.blockedUntil.After(at) {
+		at = b.blockedUntil
+	}
+	return at
+}
+
+// observe updates the bucket from a limit header ("20:1,100:120",
+// limit:windowSeconds pairs) and its matching count header. Spacing
+// follows the tightest window; an exhausted window blocks for that
+// window's length, the only safe bound without knowing when it resets.
+func (b *bucket) observe(limits, counts string) {
+	if limits == "" {
+		return
+	}
+	lim := parseRateWindows(limits)
+	cnt := parseRateWindows(counts)
+	var interval, blocked time.Duration
+	for window, limit := range lim {
+		if limit <= 0 || window <= 0 {
+			continue
+		}
+		if sp := time.Duration(window) * time.Second / time.Duration(limit); sp > interval {
+			interval = sp
+		}
+		if cnt[window] >= limit {
+			if w := time.Duration(window) * time.Second; w > blocked {
+				blocked = w
+			}
+		}
+	}
+	if interval > 0 {
+		b.interval = interval
+	}
+	if until := time.Now().Add(blocked); blocked > 0 && until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// parseRateWindows parses "v:w,v:w" header syntax into a windowSeconds
+// → value map; malformed pairs are skipped.
+func parseRateWindows(s string) map[int]int {
+	out := map[int]int{}
+	for _, pair := range strings.Split(s, ",") {
+		value, window, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			continue
+		}
+		v, err1 := strconv.Atoi(value)
+		w, err2 := strconv.Atoi(window)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		out[w] = v
+	}
+	return out
+}
+
+func (l *limiter) bucket(m *map[string]*bucket, key string) *bucket {
+	if *m == nil {
+		*m = map[string]*bucket{}
+	}
+	b := (*m)[key]
+	if b == nil {
+		b = &bucket{}
+		(*m)[key] = b
+	}
+	return b
+}
+
+// wait blocks until both the host's app budget and the method's budget
+// allow another request, then stamps them. Requests stay serialized
+// through the mutex, as before, so sleeps also space concurrent callers.
+func (l *limiter) wait(host, method string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	app := l.bucket(&l.app, host)
+	meth := l.bucket(&l.method, host+" "+method)
+	at := app.next()
+	if mn := meth.next(); mn.After(at) {
+		at = mn
+	}
+	if wait := time.Until(at); wait > 0 {
+		time.Sleep(wait)
+	}
+	now := time.Now()
+	app.lastCall, meth.lastCall = now, now
+}
+
+// observe feeds one response's rate-limit headers back into the pacing
+// state.
+func (l *limiter) observe(host, method string, h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bucket(&l.app, host).observe(h.Get("X-App-Rate-Limit"), h.Get("X-App-Rate-Limit-Count"))
+	l.bucket(&l.method, host+" "+method).observe(h.Get("X-Method-Rate-Limit"), h.Get("X-Method-Rate-Limit-Count"))
+}
+
+// block applies a 429's Retry-After to both scopes.
+func (l *limiter) block(host, method string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	until := time.Now().Add(d)
+	for _, b := range []*bucket{l.bucket(&l.app, host), l.bucket(&l.method, host+" "+method)} {
+		if until.After(b.blockedUntil) {
+			b.blockedUntil = until
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package riotapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRateWindows(t *testing.T) {
+	got := parseRateWindows("20:1,100:120")
+	if got[1] != 20 || got[120] != 100 {
+		t.Fatalf("parseRateWindows = %v", got)
+	}
+	if got := parseRateWindows("garbage"); len(got) != 0 {
+		t.Fatalf("malformed input parsed to %v", got)
+	}
+}
+
+func TestBucketObserveAdaptsInterval(t *testing.T) {
+	var b bucket
+	// Production-style app limit: 500 / 10 s → 20 ms spacing.
+	b.observe("500:10,30000:600", "1:10,1:600")
+	if b.interval != 20*time.Millisecond {
+		t.Fatalf("interval = %v, want 20ms", b.interval)
+	}
+	// Dev key: the 2-minute window is the tight one.
+	b.observe("20:1,100:120", "1:1,1:120")
+	if b.interval != minInterval {
+		t.Fatalf("interval = %v, want %v", b.interval, minInterval)
+	}
+}
+
+func TestBucketObserveExhaustedWindowBlocks(t *testing.T) {
+	var b bucket
+	b.observe("20:1", "20:1")
+	if remaining := time.Until(b.blockedUntil); remaining <= 0 || remaining > time.Second {
+		t.Fatalf("blockedUntil %v from now, want within (0, 1s]", remaining)
+	}
+}
+
+func TestBucketNextDefaultsToMinInterval(t *testing.T) {
+	b := bucket{lastCall: time.Now()}
+	if until := time.Until(b.next()); until < minInterval-50*time.Millisecond {
+		t.Fatalf("cold bucket allows next request in %v, want ~%v", until, minInterval)
+	}
+}
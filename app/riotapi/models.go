@@ -0,0 +1,223 @@
+package riotapi
+
+// AccountDTO is the account-v1 representation of a Riot account.
+type AccountDTO struct {
+	PUUID    string `json:"puuid"`
+	GameName string `json:"gameName"`
+	TagLine  string `json:"tagLine"`
+}
+
+// ActiveShardDTO is the account-v1 answer to "where does this PUUID
+// actively play".
+type ActiveShardDTO struct {
+	PUUID  string `json:"puuid"`
+	Game   string `json:"game"`
+	Region string `json:"region"`
+}
+
+// SummonerDTO is the summoner-v4 record behind an account.
+type SummonerDTO struct {
+	ID            string `json:"id"`
+	PUUID         string `json:"puuid"`
+	SummonerLevel int    `json:"summonerLevel"`
+}
+
+// LeagueEntryDTO is one ranked queue entry from league-v4.
+type LeagueEntryDTO struct {
+	QueueType    string `json:"queueType"`
+	Tier         string `json:"tier"`
+	Rank         string `json:"rank"`
+	LeaguePoints int    `json:"leaguePoints"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+}
+
+// QueueSolo and QueueFlex are the league-v4 queueType values the bot
+// tracks. (TFT ranks live behind a separate API and are not covered.)
+const (
+	QueueSolo = "RANKED_SOLO_5x5"
+	QueueFlex = "RANKED_FLEX_SR"
+)
+
+// QueueIDSolo and QueueIDFlex are the match-v5 queueIds for the ranked
+// queues the bot tracks.
+const (
+	QueueIDSolo = 420
+	QueueIDFlex = 440
+)
+
+// ChampionMasteryDTO is one champion-mastery-v4 entry.
+type ChampionMasteryDTO struct {
+	ChampionID     int64 `json:"championId"`
+	ChampionLevel  int   `json:"championLevel"`
+	ChampionPoints int   `json:"championPoints"`
+	LastPlayTime   int64 `json:"lastPlayTime"`
+}
+
+// MatchDTO is a match-v5 match.
+type MatchDTO struct {
+	Metadata MetadataDTO `json:"metadata"`
+	Info     InfoDTO     `json:"info"`
+}
+
+// MetadataDTO carries the schema version, match ID and participant
+// PUUIDs.
+type MetadataDTO struct {
+	DataVersion  string   `json:"dataVersion"`
+	MatchID      string   `json:"matchId"`
+	Participants []string `json:"participants"`
+}
+
+// InfoDTO carries the gameplay payload of a match.
+type InfoDTO struct {
+	GameCreation     int64            `json:"gameCreation"`
+	GameEndTimestamp int64            `json:"gameEndTimestamp"`
+	GameDuration     int64            `json:"gameDuration"`
+	GameVersion      string           `json:"gameVersion"`
+	GameMode         string           `json:"gameMode"`
+	PlatformID       string           `json:"platformId"`
+	QueueID          int              `json:"queueId"`
+	Participants     []ParticipantDTO `json:"participants"`
+	Teams            []TeamDTO        `json:"teams"`
+}
+
+// TeamDTO is one side's bans and objective tallies.
+type TeamDTO struct {
+	TeamID     int           `json:"teamId"`
+	Win        bool          `json:"win"`
+	Bans       []BanDTO      `json:"bans"`
+	Objectives ObjectivesDTO `json:"objectives"`
+}
+
+// BanDTO is one champion ban; ChampionID is -1 for a skipped ban.
+type BanDTO struct {
+	ChampionID int `json:"championId"`
+	PickTurn   int `json:"pickTurn"`
+}
+
+// ObjectivesDTO groups a team's objective counters.
+type ObjectivesDTO struct {
+	Baron      ObjectiveDTO `json:"baron"`
+	Champion   ObjectiveDTO `json:"champion"`
+	Dragon     ObjectiveDTO `json:"dragon"`
+	Horde      ObjectiveDTO `json:"horde"`
+	Inhibitor  ObjectiveDTO `json:"inhibitor"`
+	RiftHerald ObjectiveDTO `json:"riftHerald"`
+	Tower      ObjectiveDTO `json:"tower"`
+}
+
+// ObjectiveDTO is one objective's tally.
+type ObjectiveDTO struct {
+	First bool `json:"first"`
+	Kills int  `json:"kills"`
+}
+
+// ParticipantDTO is one player's slice of a match.
+type ParticipantDTO struct {
+	PUUID          string `json:"puuid"`
+	RiotIDGameName string `json:"riotIdGameName"`
+	RiotIDTagline  string `json:"riotIdTagline"`
+	TeamID         int    `json:"teamId"`
+	Win            bool   `json:"win"`
+	// TeamPosition is the assigned position: TOP, JUNGLE, MIDDLE,
+	// BOTTOM or UTILITY (empty for non-SR queues).
+	TeamPosition string `json:"teamPosition"`
+	ChampionID   int    `json:"championId"`
+	ChampionName string `json:"championName"`
+	ChampLevel   int    `json:"champLevel"`
+	Kills        int    `json:"kills"`
+	Deaths       int    `json:"deaths"`
+	Assists      int    `json:"assists"`
+	GoldEarned   int    `json:"goldEarned"`
+	VisionScore  int    `json:"visionScore"`
+
+	DoubleKills int `json:"doubleKills"`
+	TripleKills int `json:"tripleKills"`
+	QuadraKills int `json:"quadraKills"`
+	PentaKills  int `json:"pentaKills"`
+
+	// GameEndedInSurrender is set on every participant when the game
+	// finished with a surrender vote (early or regular).
+	GameEndedInSurrender      bool `json:"gameEndedInSurrender"`
+	GameEndedInEarlySurrender bool `json:"gameEndedInEarlySurrender"`
+
+	TotalDamageDealtToChampions int `json:"totalDamageDealtToChampions"`
+	DamageDealtToObjectives     int `json:"damageDealtToObjectives"`
+	TotalDamageTaken            int `json:"totalDamageTaken"`
+	TotalHealsOnTeammates       int `json:"totalHealsOnTeammates"`
+	TotalMinionsKilled          int `json:"totalMinionsKilled"`
+	NeutralMinionsKilled        int `json:"neutralMinionsKilled"`
+	WardsPlaced                 int `json:"wardsPlaced"`
+	WardsKilled                 int `json:"wardsKilled"`
+	TimePlayed                  int `json:"timePlayed"`
+
+	Challenges ChallengesDTO `json:"challenges"`
+	Perks      PerksDTO      `json:"perks"`
+}
+
+// ChallengesDTO is the match-v5 challenges blob. Riot adds and drops
+// keys every patch, so it is modeled as a map rather than a struct;
+// Value looks a numeric challenge up by key.
+type ChallengesDTO map[string]interface{}
+
+// Value returns a numeric challenge by its key (e.g. "kda",
+// "damagePerMinute"), reporting whether it was present.
+func (c ChallengesDTO) Value(key string) (float64, bool) {
+	v, ok := c[key].(float64)
+	return v, ok
+}
+
+// PerksDTO is a participant's rune page.
+type PerksDTO struct {
+	StatPerks StatPerksDTO   `json:"statPerks"`
+	Styles    []PerkStyleDTO `json:"styles"`
+}
+
+// StatPerksDTO holds the three stat shard choices.
+type StatPerksDTO struct {
+	Defense int `json:"defense"`
+	Flex    int `json:"flex"`
+	Offense int `json:"offense"`
+}
+
+// PerkStyleDTO is one rune tree (primary or sub) with its selections.
+type PerkStyleDTO struct {
+	Description string             `json:"description"`
+	Style       int                `json:"style"`
+	Selections  []PerkSelectionDTO `json:"selections"`
+}
+
+// PerkSelectionDTO is one chosen rune and its stat vars.
+type PerkSelectionDTO struct {
+	Perk int `json:"perk"`
+	Var1 int `json:"var1"`
+	Var2 int `json:"var2"`
+	Var3 int `json:"var3"`
+}
+
+// CurrentGameInfoDTO is a spectator-v5 live game.
+type CurrentGameInfoDTO struct {
+	GameID            int64                       `json:"gameId"`
+	GameQueueConfigID int                         `json:"gameQueueConfigId"`
+	GameLength        int64                       `json:"gameLength"`
+	Participants      []CurrentGameParticipantDTO `json:"participants"`
+}
+
+// CurrentGameParticipantDTO is one player inside a live game.
+type CurrentGameParticipantDTO struct {
+	PUUID      string `json:"puuid"`
+	SummonerID string `json:"summonerId"`
+	RiotID     string `json:"riotId"`
+	ChampionID int64  `json:"championId"`
+	TeamID     int    `json:"teamId"`
+}
+
+// RiotID renders the participant's gameName#tagLine pair.
+func (p *ParticipantDTO) RiotID() string {
+	return p.RiotIDGameName + "#" + p.RiotIDTagline
+}
+
+// CS is total lane plus jungle creeps.
+func (p *ParticipantDTO) CS() int {
+	return p.TotalMinionsKilled + p.NeutralMinionsKilled
+}
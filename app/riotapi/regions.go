@@ -0,0 +1,27 @@
+package riotapi
+
+import "strings"
+
+// platformRegions maps platform routing values (summoner-v4, league-v4)
+// to the regional routing values serving them (account-v1, match-v5).
+var platformRegions = map[string]string{
+	"br1": "americas", "la1": "americas", "la2": "americas", "na1": "americas",
+	"jp1": "asia", "kr": "asia",
+	"oc1": "sea", "ph2": "sea", "sg2": "sea", "th2": "sea", "tw2": "sea", "vn2": "sea",
+	"eun1": "europe", "euw1": "europe", "ru": "europe", "tr1": "europe",
+}
+
+// Region returns the regional routing value for a platform, falling
+// back to asia for unknown inputs.
+func Region(platform string) string {
+	if r, ok := platformRegions[strings.ToLower(platform)]; ok {
+		return r
+	}
+	return "asia"
+}
+
+// ValidPlatform reports whether platform is a known routing value.
+func ValidPlatform(platform string) bool {
+	_, ok := platformRegions[strings.ToLower(platform)]
+	return ok
+}
@@ -0,0 +1,56 @@
+package riotapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrMalformed flags a response that decoded fine but fails sanity
+// checks — usually a sign the API schema shifted under us. Rejecting it
+// here keeps garbage out of the cache and the rendered output.
+var ErrMalformed = errors.New("riot api: malformed response")
+
+// matchEpochMilli is just before the earliest possible match timestamp;
+// anything at or below it (notably zero) is garbage.
+var matchEpochMilli = time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+func (a *AccountDTO) validate() error {
+	if a.PUUID == "" {
+		return fmt.Errorf("%w: account has no puuid", ErrMalformed)
+	}
+	return nil
+}
+
+func (s *SummonerDTO) validate() error {
+	if s.ID == "" || s.PUUID == "" {
+		return fmt.Errorf("%w: summoner missing id or puuid", ErrMalformed)
+	}
+	return nil
+}
+
+func (e *LeagueEntryDTO) validate() error {
+	if e.QueueType == "" || e.Tier == "" {
+		return fmt.Errorf("%w: league entry missing queue or tier", ErrMalformed)
+	}
+	return nil
+}
+
+func (m *MatchDTO) validate() error {
+	if m.Metadata.MatchID == "" {
+		return fmt.Errorf("%w: match has no id", ErrMalformed)
+	}
+	if m.Info.QueueID < 0 {
+		return fmt.Errorf("%w: match %s has queue id %d", ErrMalformed, m.Metadata.MatchID, m.Info.QueueID)
+	}
+	end := m.Info.GameEndTimestamp
+	if end <= matchEpochMilli || end > time.Now().Add(24*time.Hour).UnixMilli() {
+		return fmt.Errorf("%w: match %s has end timestamp %d", ErrMalformed, m.Metadata.MatchID, end)
+	}
+	for i := range m.Info.Participants {
+		if m.Info.Participants[i].PUUID == "" {
+			return fmt.Errorf("%w: match %s participant %d has no puuid", ErrMalformed, m.Metadata.MatchID, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package riotapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func validMatch() MatchDTO {
+	return MatchDTO{
+		Metadata: MetadataDTO{MatchID: "JP1_1"},
+		Info: InfoDTO{
+			QueueID:          QueueIDSolo,
+			GameEndTimestamp: time.Now().UnixMilli(),
+			Participants:     []ParticipantDTO{{PUUID: "p"}},
+		},
+	}
+}
+
+func TestMatchValidate(t *testing.T) {
+	m := validMatch()
+	if err := m.validate(); err != nil {
+		t.Fatalf("valid match rejected: %v", err)
+	}
+
+	for name, mutate := range map[string]func(*MatchDTO){
+		"no id":                func(m *MatchDTO) { m.Metadata.MatchID = "" },
+		"zero timestamp":       func(m *MatchDTO) { m.Info.GameEndTimestamp = 0 },
+		"future":               func(m *MatchDTO) { m.Info.GameEndTimestamp = time.Now().AddDate(0, 0, 2).UnixMilli() },
+		"negative queue":       func(m *MatchDTO) { m.Info.QueueID = -1 },
+		"no participant puuid": func(m *MatchDTO) { m.Info.Participants[0].PUUID = "" },
+	} {
+		m := validMatch()
+		mutate(&m)
+		if err := m.validate(); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%s: err = %v, want ErrMalformed", name, err)
+		}
+	}
+}
+
+func TestAccountValidate(t *testing.T) {
+	a := AccountDTO{}
+	if err := a.validate(); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("err = %v, want ErrMalformed", err)
+	}
+	a.PUUID = "p"
+	if err := a.validate(); err != nil {
+		t.Fatalf("valid account rejected: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Package sched is a thin cron wrapper the worker registers all of its
+// periodic jobs with. Job times are evaluated in a fixed location so
+// "0 5 * * *" means 05:00 local bot time regardless of the host clock.
+package sched
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// parser accepts standard five-field cron expressions plus the
+// @hourly/@daily descriptors.
+var parser = cron.NewParser(
+	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// Scheduler runs named jobs on cron expressions.
+type Scheduler struct {
+	cron *cron.Cron
+}
+
+// New builds a Scheduler evaluating expressions in loc.
+func New(loc *time.Location) *Scheduler {
+	return &Scheduler{cron: cron.New(cron.WithLocation(loc), cron.WithParser(parser))}
+}
+
+// Add registers a job under a cron expression. Panics inside the job
+// are caught and logged so one bad job cannot take the worker down.
+func (s *Scheduler) Add(name, spec string, fn func()) error {
+	_, err := s.cron.AddFunc(spec, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("sched: job %s panicked: %v", name, r)
+			}
+		}()
+		fn()
+	})
+	if err != nil {
+		return fmt.Errorf("sched: job %s: %w", name, err)
+	}
+	return nil
+}
+
+// Start launches the scheduler in its own goroutine.
+func (s *Scheduler) Start() { s.cron.Start() }
+
+// Stop stops the scheduler and waits for running jobs to finish.
+func (s *Scheduler) Stop() { <-s.cron.Stop().Done() }
+
+// Validate reports whether spec is a parseable cron expression.
+func Validate(spec string) error {
+	_, err := parser.Parse(spec)
+	return err
+}
+
+// Due reports whether a cron expression fires at the given minute.
+// It lets per-guild expressions stored in the database be checked from
+// a minutely sweep without re-registering cron entries on every edit.
+func Due(spec string, at time.Time) bool {
+	sched, err := parser.Parse(spec)
+	if err != nil {
+		return false
+	}
+	minute := at.Truncate(time.Minute)
+	return sched.Next(minute.Add(-time.Second)).Equal(minute)
+}
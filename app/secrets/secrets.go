@@ -0,0 +1,75 @@
+// Package secrets encrypts sensitive stored values (guild-provided API
+// keys and the like) at rest with AES-256-GCM. The key is derived from
+// the SECRET_KEY environment variable, so the database file alone never
+// reveals a secret.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// prefix marks encrypted values so plaintext written before a key was
+// configured still reads back unchanged.
+const prefix = "enc:"
+
+// Box encrypts and decrypts short string values.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New derives a 256-bit key from the given passphrase and returns a
+// ready Box. An empty passphrase is an error; callers decide whether to
+// run without encryption instead.
+func New(passphrase string) (*Box, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("secrets: empty key")
+	}
+	sum := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Encrypt seals a value under a fresh nonce.
+func (b *Box) Encrypt(plain string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value sealed by Encrypt. Values without the encrypted
+// marker pass through unchanged, so data from before encryption was
+// enabled keeps working.
+func (b *Box) Decrypt(stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, prefix)
+	if !ok {
+		return stored, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("secrets: decode: %w", err)
+	}
+	if len(sealed) < b.aead.NonceSize() {
+		return "", fmt.Errorf("secrets: value too short")
+	}
+	plain, err := b.aead.Open(nil, sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("secrets: open: %w", err)
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,53 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	box, err := New("test-passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := box.Encrypt("RGAPI-secret-value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealed, "enc:") {
+		t.Fatalf("sealed value %q lacks marker", sealed)
+	}
+	plain, err := box.Decrypt(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain != "RGAPI-secret-value" {
+		t.Fatalf("got %q", plain)
+	}
+}
+
+func TestPlaintextPassthrough(t *testing.T) {
+	box, err := New("test-passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := box.Decrypt("legacy-plaintext")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain != "legacy-plaintext" {
+		t.Fatalf("got %q", plain)
+	}
+}
+
+func TestWrongKeyFails(t *testing.T) {
+	box, _ := New("key-one")
+	other, _ := New("key-two")
+	sealed, err := box.Encrypt("value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Decrypt(sealed); err == nil {
+		t.Fatal("decrypt with wrong key succeeded")
+	}
+}
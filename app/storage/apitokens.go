@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// API token scopes. A token carries one or more of these; the HTTP
+// server checks them per endpoint.
+const (
+	ScopeRanking = "ranking" // leaderboard endpoints
+	ScopeStats   = "stats"   // player and stats endpoints
+)
+
+// ValidScope reports whether scope is a known token scope.
+func ValidScope(scope string) bool {
+	return scope == ScopeRanking || scope == ScopeStats
+}
+
+// APIToken is one admin-issued credential for the HTTP API. The token
+// value itself is only shown at creation; the database keeps a SHA-256
+// hash.
+type APIToken struct {
+	ID        int64
+	GuildID   string
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+}
+
+// HasScope reports whether the token grants the given scope.
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIToken mints a token for a guild and returns its plaintext —
+// the only time it is ever available. name must be unique per guild.
+func (s *Store) CreateAPIToken(guildID, name string, scopes []string) (string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	_, err := s.db.Exec(`
+		INSERT INTO api_tokens (guild_id, name, token_hash, scopes, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		guildID, name, hashAPIToken(token), strings.Join(scopes, ","), now())
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeAPIToken deletes a guild's token by name, reporting whether one
+// existed.
+func (s *Store) RevokeAPIToken(guildID, name string) (bool, error) {
+	res, err := s.db.Exec(`DELETE FROM api_tokens WHERE guild_id = ? AND name = ?`, guildID, name)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// APITokens lists a guild's tokens, oldest first.
+func (s *Store) APITokens(guildID string) ([]APIToken, error) {
+	rows, err := s.db.Query(`
+		SELECT id, guild_id, name, scopes, created_at FROM api_tokens
+		WHERE guild_id = ? ORDER BY id`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tokens []APIToken
+	for rows.Next() {
+		t, err := scanAPIToken(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *t)
+	}
+	return tokens, rows.Err()
+}
+
+// APITokenByToken resolves a presented token by its hash; nil means the
+// token is unknown (or was revoked).
+func (s *Store) APITokenByToken(token string) (*APIToken, error) {
+	row := s.db.QueryRow(`
+		SELECT id, guild_id, name, scopes, created_at FROM api_tokens
+		WHERE token_hash = ?`, hashAPIToken(token))
+	t, err := scanAPIToken(row.Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return t, err
+}
+
+// AnyAPITokens reports whether any token exists across all guilds; the
+// HTTP server leaves cross-guild endpoints open until the first one is
+// issued.
+func (s *Store) AnyAPITokens() (bool, error) {
+	var n int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM api_tokens`).Scan(&n)
+	return n > 0, err
+}
+
+func scanAPIToken(scan func(...interface{}) error) (*APIToken, error) {
+	var t APIToken
+	var scopes string
+	var createdAt int64
+	if err := scan(&t.ID, &t.GuildID, &t.Name, &scopes, &createdAt); err != nil {
+		return nil, err
+	}
+	if scopes != "" {
+		t.Scopes = strings.Split(scopes, ",")
+	}
+	t.CreatedAt = time.Unix(createdAt, 0)
+	return &t, nil
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// Instance-wide setting keys. Unlike guild settings these apply to the
+// whole deployment and are shared by both processes through the database.
+const (
+	// AppSettingMaintenance pauses schedulers and command handling while
+	// the operator works on the instance ("on"/"off"; off by default).
+	AppSettingMaintenance = "maintenance"
+)
+
+// SetAppSetting stores one instance-wide configuration value.
+func (s *Store) SetAppSetting(key, value string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO app_settings (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, key, value)
+	return err
+}
+
+// AppSetting returns an instance-wide configuration value, falling back
+// to the given default when none is stored.
+func (s *Store) AppSetting(key, fallback string) (string, error) {
+	var value string
+	err := s.db.QueryRow(`SELECT value FROM app_settings WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fallback, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,27 @@
+package storage
+
+import "time"
+
+// MarkMessageProcessed records a Discord message ID and reports whether
+// this was the first time it was seen. The gateway uses it to drop
+// events Discord redelivers after a reconnect.
+func (s *Store) MarkMessageProcessed(messageID string) (first bool, err error) {
+	res, err := s.db.Exec(`
+		INSERT OR IGNORE INTO processed_messages (message_id, seen_at) VALUES (?, ?)`,
+		messageID, now())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// PruneProcessedMessages forgets message IDs seen before cutoff;
+// redeliveries only happen within a session, so a short window is fine.
+func (s *Store) PruneProcessedMessages(cutoff time.Time) (int64, error) {
+	res, err := s.db.Exec(`DELETE FROM processed_messages WHERE seen_at < ?`, cutoff.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
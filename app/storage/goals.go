@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// CurrentSeason identifies the season goals are scoped to; the bot
+// treats each calendar year as one season.
+func CurrentSeason() string { return strconv.Itoa(time.Now().Year()) }
+
+// Goal is a player's rank target for one season.
+type Goal struct {
+	PlayerID   int64
+	Season     string
+	Tier       string
+	Division   string
+	AchievedAt time.Time // zero until reached
+}
+
+// SetGoal stores (or replaces) a player's goal for a season; changing
+// the goal clears any earlier achievement.
+func (s *Store) SetGoal(playerID int64, season, tier, division string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO goals (player_id, season, tier, division, achieved_at, created_at)
+		VALUES (?, ?, ?, ?, 0, ?)
+		ON CONFLICT (player_id, season) DO UPDATE
+		SET tier = excluded.tier, division = excluded.division, achieved_at = 0`,
+		playerID, season, tier, division, now())
+	return err
+}
+
+// GoalFor returns a player's goal for a season, or nil if none is set.
+func (s *Store) GoalFor(playerID int64, season string) (*Goal, error) {
+	row := s.db.QueryRow(`
+		SELECT tier, division, achieved_at FROM goals
+		WHERE player_id = ? AND season = ?`, playerID, season)
+	g := Goal{PlayerID: playerID, Season: season}
+	var achievedAt int64
+	err := row.Scan(&g.Tier, &g.Division, &achievedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if achievedAt > 0 {
+		g.AchievedAt = time.Unix(achievedAt, 0)
+	}
+	return &g, nil
+}
+
+// MarkGoalAchieved records the moment a goal was first reached; calling
+// it again is a no-op.
+func (s *Store) MarkGoalAchieved(playerID int64, season string) error {
+	_, err := s.db.Exec(`
+		UPDATE goals SET achieved_at = ?
+		WHERE player_id = ? AND season = ? AND achieved_at = 0`,
+		now(), playerID, season)
+	return err
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// EnsureGuild creates the guild row on first contact.
+func (s *Store) EnsureGuild(guildID string) error {
+	_, err := s.db.Exec(`
+		INSERT OR IGNORE INTO guilds (guild_id, created_at) VALUES (?, ?)`,
+		guildID, now())
+	return err
+}
+
+// GuildKnown reports whether the guild has been seen before.
+func (s *Store) GuildKnown(guildID string) (bool, error) {
+	var one int
+	err := s.db.QueryRow(`SELECT 1 FROM guilds WHERE guild_id = ?`, guildID).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// SetAnnounceChannel records where scheduled posts for the guild go.
+func (s *Store) SetAnnounceChannel(guildID, channelID string) error {
+	if err := s.EnsureGuild(guildID); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`
+		UPDATE guilds SET announce_channel_id = ? WHERE guild_id = ?`,
+		channelID, guildID)
+	return err
+}
+
+// AnnounceChannel returns the guild's configured announcement channel,
+// or "" when none has been set.
+func (s *Store) AnnounceChannel(guildID string) (string, error) {
+	var ch string
+	err := s.db.QueryRow(`
+		SELECT announce_channel_id FROM guilds WHERE guild_id = ?`, guildID).Scan(&ch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return ch, err
+}
+
+// AnnounceChannelFor resolves the channel for one announcement type
+// (a SettingChannel* key), falling back to the guild default channel.
+func (s *Store) AnnounceChannelFor(guildID, settingKey string) (string, error) {
+	ch, err := s.GuildSetting(guildID, settingKey, "")
+	if err != nil || ch != "" {
+		return ch, err
+	}
+	return s.AnnounceChannel(guildID)
+}
+
+// GuildIDs lists every guild the bot has seen.
+func (s *Store) GuildIDs() ([]string, error) {
+	rows, err := s.db.Query(`SELECT guild_id FROM guilds ORDER BY guild_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
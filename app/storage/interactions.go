@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// InteractionState is the state behind one message's components
+// (pagination cursors and the like), persisted so buttons keep working
+// across gateway restarts.
+type InteractionState struct {
+	MessageID string
+	GuildID   string
+	Kind      string
+	Payload   string
+}
+
+// SaveInteractionState stores (or replaces) the state for a message.
+func (s *Store) SaveInteractionState(messageID, guildID, kind, payload string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO interaction_states (message_id, guild_id, kind, payload, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (message_id) DO UPDATE SET payload = excluded.payload`,
+		messageID, guildID, kind, payload, now())
+	return err
+}
+
+// InteractionState returns the state behind a message, or nil when none
+// is stored (never saved, already consumed, or pruned).
+func (s *Store) InteractionState(messageID string) (*InteractionState, error) {
+	var st InteractionState
+	err := s.db.QueryRow(`
+		SELECT message_id, guild_id, kind, payload FROM interaction_states
+		WHERE message_id = ?`, messageID).
+		Scan(&st.MessageID, &st.GuildID, &st.Kind, &st.Payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// DeleteInteractionState forgets a message's state once it is consumed.
+func (s *Store) DeleteInteractionState(messageID string) error {
+	_, err := s.db.Exec(`DELETE FROM interaction_states WHERE message_id = ?`, messageID)
+	return err
+}
+
+// PruneInteractionStates forgets states saved before cutoff; components
+// on messages that old are stale anyway.
+func (s *Store) PruneInteractionStates(cutoff time.Time) (int64, error) {
+	res, err := s.db.Exec(`DELETE FROM interaction_states WHERE created_at < ?`, cutoff.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
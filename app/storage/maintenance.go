@@ -0,0 +1,80 @@
+package storage
+
+import "time"
+
+// PruneMatches deletes cached matches whose game ended before cutoff,
+// along with their player links, and returns how many were removed.
+func (s *Store) PruneMatches(cutoff time.Time) (int64, error) {
+	if _, err := s.db.Exec(`
+		DELETE FROM player_matches WHERE match_id IN (
+			SELECT match_id FROM matches WHERE game_end < ?
+		)`, cutoff.Unix()); err != nil {
+		return 0, err
+	}
+	res, err := s.db.Exec(`DELETE FROM matches WHERE game_end < ?`, cutoff.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// PruneSnapshots deletes snapshots taken before cutoff, always keeping
+// each player's newest snapshot per queue so rankings stay renderable.
+func (s *Store) PruneSnapshots(cutoff time.Time) (int64, error) {
+	res, err := s.db.Exec(`
+		DELETE FROM snapshots WHERE taken_at < ? AND id NOT IN (
+			SELECT MAX(id) FROM snapshots GROUP BY player_id, queue
+		)`, cutoff.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// PruneTasks deletes finished and failed tasks older than cutoff.
+func (s *Store) PruneTasks(cutoff time.Time) (int64, error) {
+	res, err := s.db.Exec(`
+		DELETE FROM tasks WHERE status IN ('done', 'failed') AND created_at < ?`,
+		cutoff.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Stats summarizes what the database currently holds.
+type Stats struct {
+	SizeBytes int64
+	Players   int64
+	Snapshots int64
+	Matches   int64
+	Tasks     int64
+}
+
+// DBStats reports the database file size and per-table row counts.
+func (s *Store) DBStats() (*Stats, error) {
+	var st Stats
+	var pageCount, pageSize int64
+	if err := s.db.QueryRow(`PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRow(`PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return nil, err
+	}
+	st.SizeBytes = pageCount * pageSize
+	counts := []struct {
+		table string
+		dst   *int64
+	}{
+		{"players", &st.Players},
+		{"snapshots", &st.Snapshots},
+		{"matches", &st.Matches},
+		{"tasks", &st.Tasks},
+	}
+	for _, c := range counts {
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM ` + c.table).Scan(c.dst); err != nil {
+			return nil, err
+		}
+	}
+	return &st, nil
+}
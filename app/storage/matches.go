@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// SaveMatch stores a fetched match and links it to the player it was
+// fetched for. The match payload is stored once even when several
+// registered players shared the game.
+func (s *Store) SaveMatch(playerID int64, m *riotapi.MatchDTO) error {
+	if err := s.CacheMatch(m); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`
+		INSERT OR IGNORE INTO player_matches (player_id, match_id) VALUES (?, ?)`,
+		playerID, m.Metadata.MatchID)
+	return err
+}
+
+// CacheMatch stores a match without linking it to a roster entry, for
+// lookups of games no registered player took part in.
+func (s *Store) CacheMatch(m *riotapi.MatchDTO) error {
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		INSERT OR IGNORE INTO matches (match_id, queue_id, game_end, payload, fetched_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		m.Metadata.MatchID, m.Info.QueueID, m.Info.GameEndTimestamp/1000, string(payload), now())
+	return err
+}
+
+// MatchByID returns one cached match, or nil when it is not cached.
+func (s *Store) MatchByID(matchID string) (*riotapi.MatchDTO, error) {
+	var payload string
+	err := s.db.QueryRow(`SELECT payload FROM matches WHERE match_id = ?`, matchID).Scan(&payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m riotapi.MatchDTO
+	if err := json.Unmarshal([]byte(payload), &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// HasMatch reports whether a match is already cached for the player.
+func (s *Store) HasMatch(playerID int64, matchID string) (bool, error) {
+	var one int
+	err := s.db.QueryRow(`
+		SELECT 1 FROM player_matches WHERE player_id = ? AND match_id = ?`,
+		playerID, matchID).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// RecentMatchesForPlayer returns the player's newest cached matches,
+// at most limit of them.
+func (s *Store) RecentMatchesForPlayer(playerID int64, limit int) ([]riotapi.MatchDTO, error) {
+	rows, err := s.db.Query(`
+		SELECT m.payload
+		FROM matches m
+		JOIN player_matches pm ON pm.match_id = m.match_id
+		WHERE pm.player_id = ?
+		ORDER BY m.game_end DESC LIMIT ?`,
+		playerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMatches(rows)
+}
+
+// PlayerMatches pairs a roster entry with a set of their matches.
+type PlayerMatches struct {
+	Player
+	Matches []riotapi.MatchDTO
+}
+
+// RankedGamesPerDay counts the guild's distinct ranked games per JST
+// day since from. Days without games are absent from the map.
+func (s *Store) RankedGamesPerDay(guildID string, queueID int, from time.Time) (map[string]int, error) {
+	rows, err := s.db.Query(`
+		SELECT date(m.game_end, 'unixepoch', '+9 hours') AS day, COUNT(DISTINCT m.match_id)
+		FROM matches m
+		JOIN player_matches pm ON pm.match_id = m.match_id
+		JOIN players p ON p.id = pm.player_id
+		WHERE p.guild_id = ? AND m.queue_id = ? AND m.game_end >= ?
+		GROUP BY day`, guildID, queueID, from.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make(map[string]int)
+	for rows.Next() {
+		var day string
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+		out[day] = count
+	}
+	return out, rows.Err()
+}
+
+// RecapData collects each registered player's cached matches in
+// [from, to) for recap-style posts.
+func (s *Store) RecapData(guildID string, from, to time.Time) ([]PlayerMatches, error) {
+	players, err := s.Players(guildID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]PlayerMatches, 0, len(players))
+	for _, p := range players {
+		matches, err := s.MatchesForPlayer(p.ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, PlayerMatches{Player: p, Matches: matches})
+	}
+	return out, nil
+}
+
+// MatchesForPlayer returns the player's cached matches whose game end
+// falls inside [from, to), newest first.
+func (s *Store) MatchesForPlayer(playerID int64, from, to time.Time) ([]riotapi.MatchDTO, error) {
+	rows, err := s.db.Query(`
+		SELECT m.payload
+		FROM matches m
+		JOIN player_matches pm ON pm.match_id = m.match_id
+		WHERE pm.player_id = ? AND m.game_end >= ? AND m.game_end < ?
+		ORDER BY m.game_end DESC`,
+		playerID, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMatches(rows)
+}
+
+// LastMatchFetch returns when a player's match cache was last updated;
+// the zero time when nothing is cached. It feeds freshness footers.
+func (s *Store) LastMatchFetch(playerID int64) (time.Time, error) {
+	var fetched int64
+	err := s.db.QueryRow(`
+		SELECT COALESCE(MAX(m.fetched_at), 0)
+		FROM matches m
+		JOIN player_matches pm ON pm.match_id = m.match_id
+		WHERE pm.player_id = ?`, playerID).Scan(&fetched)
+	if err != nil || fetched == 0 {
+		return time.Time{}, err
+	}
+	return time.Unix(fetched, 0), nil
+}
+
+// GuildMatchFetch is LastMatchFetch across a guild's whole roster.
+func (s *Store) GuildMatchFetch(guildID string) (time.Time, error) {
+	var fetched int64
+	err := s.db.QueryRow(`
+		SELECT COALESCE(MAX(m.fetched_at), 0)
+		FROM matches m
+		JOIN player_matches pm ON pm.match_id = m.match_id
+		JOIN players p ON p.id = pm.player_id
+		WHERE p.guild_id = ?`, guildID).Scan(&fetched)
+	if err != nil || fetched == 0 {
+		return time.Time{}, err
+	}
+	return time.Unix(fetched, 0), nil
+}
+
+func scanMatches(rows *sql.Rows) ([]riotapi.MatchDTO, error) {
+	var out []riotapi.MatchDTO
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, err
+		}
+		var m riotapi.MatchDTO
+		if err := json.Unmarshal([]byte(payload), &m); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,104 @@
+package storage
+
+import "time"
+
+// OpsStats is the cross-guild health summary behind the operator
+// digest: fleet size, storage growth, refresh staleness and task-queue
+// health over a recent window.
+type OpsStats struct {
+	Guilds  int
+	Players int
+	// Totals plus how much the window added.
+	Snapshots    int
+	SnapshotsNew int
+	Matches      int
+	MatchesNew   int
+	// PendingTasks is the current queue backlog; FailedTasks counts the
+	// window's permanently failed tasks per kind.
+	PendingTasks int
+	FailedTasks  map[string]int
+	// Busiest ranks guilds by matches cached in the window.
+	Busiest []GuildActivity
+	// StalePlayers counts active players without a snapshot since the
+	// staleness cutoff — the signal that refreshes are erroring or rate
+	// limits are saturated. MissingPlayers counts accounts flagged 404.
+	StalePlayers   int
+	MissingPlayers int
+}
+
+// GuildActivity is one guild's cached-match volume in the window.
+type GuildActivity struct {
+	GuildID string
+	Matches int
+}
+
+// OpsStats aggregates the digest over activity since `since`, treating
+// active players without a snapshot after `staleSince` as stale.
+func (s *Store) OpsStats(since, staleSince time.Time) (*OpsStats, error) {
+	stats := &OpsStats{FailedTasks: map[string]int{}}
+	counts := []struct {
+		dest  *int
+		query string
+		args  []interface{}
+	}{
+		{&stats.Guilds, `SELECT COUNT(*) FROM guilds`, nil},
+		{&stats.Players, `SELECT COUNT(*) FROM players`, nil},
+		{&stats.Snapshots, `SELECT COUNT(*) FROM snapshots`, nil},
+		{&stats.SnapshotsNew, `SELECT COUNT(*) FROM snapshots WHERE taken_at >= ?`, []interface{}{since.Unix()}},
+		{&stats.Matches, `SELECT COUNT(*) FROM matches`, nil},
+		{&stats.MatchesNew, `SELECT COUNT(*) FROM matches WHERE fetched_at >= ?`, []interface{}{since.Unix()}},
+		{&stats.PendingTasks, `SELECT COUNT(*) FROM tasks WHERE status = 'pending'`, nil},
+		{&stats.StalePlayers, `
+			SELECT COUNT(*) FROM players p
+			WHERE p.paused = 0 AND p.state = ''
+			  AND NOT EXISTS (SELECT 1 FROM snapshots sn WHERE sn.player_id = p.id AND sn.taken_at >= ?)`,
+			[]interface{}{staleSince.Unix()}},
+		{&stats.MissingPlayers, `SELECT COUNT(*) FROM players WHERE state = ?`, []interface{}{StateMissing}},
+	}
+	for _, c := range counts {
+		if err := s.db.QueryRow(c.query, c.args...).Scan(c.dest); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.db.Query(`
+		SELECT kind, COUNT(*) FROM tasks
+		WHERE status = 'failed' AND created_at >= ?
+		GROUP BY kind`, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var kind string
+		var n int
+		if err := rows.Scan(&kind, &n); err != nil {
+			return nil, err
+		}
+		stats.FailedTasks[kind] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	busiest, err := s.db.Query(`
+		SELECT p.guild_id, COUNT(DISTINCT pm.match_id)
+		FROM player_matches pm
+		JOIN players p ON p.id = pm.player_id
+		JOIN matches m ON m.match_id = pm.match_id
+		WHERE m.fetched_at >= ?
+		GROUP BY p.guild_id
+		ORDER BY 2 DESC LIMIT 3`, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer busiest.Close()
+	for busiest.Next() {
+		var a GuildActivity
+		if err := busiest.Scan(&a.GuildID, &a.Matches); err != nil {
+			return nil, err
+		}
+		stats.Busiest = append(stats.Busiest, a)
+	}
+	return stats, busiest.Err()
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrNotRegistered is returned when a player lookup finds no roster entry.
+var ErrNotRegistered = errors.New("player is not registered")
+
+// Player is one registered roster entry.
+type Player struct {
+	ID         int64
+	GuildID    string
+	GameName   string
+	TagLine    string
+	PUUID      string
+	SummonerID string
+	Platform   string
+	// DiscordUserID links the account to a Discord user; empty when
+	// nobody has claimed it with !me link.
+	DiscordUserID string
+	// Paused marks the player inactive: refreshes skip them and the
+	// ranking greys them out. PausedUntil bounds the pause; zero means
+	// until explicitly unpaused.
+	Paused      bool
+	PausedUntil time.Time
+	// State flags accounts the refresh cycle can no longer serve:
+	// StateMissing after the API starts returning 404s (transferred,
+	// banned or renamed), StateInactive after months without matches.
+	// Empty means healthy.
+	State string
+	// Priority marks the player for frequent refreshes (every few
+	// minutes instead of the hourly guild slot) — for a promotion push
+	// the whole group is watching.
+	Priority bool
+}
+
+// Account states set by the worker's refresh cycle.
+const (
+	StateMissing  = "missing"
+	StateInactive = "inactive"
+)
+
+// RiotID renders the player's gameName#tagLine pair.
+func (p *Player) RiotID() string { return p.GameName + "#" + p.TagLine }
+
+// IsPaused reports whether the player is inactive at t; a bounded pause
+// expires on its own once t passes PausedUntil.
+func (p *Player) IsPaused(t time.Time) bool {
+	if !p.Paused {
+		return false
+	}
+	return p.PausedUntil.IsZero() || t.Before(p.PausedUntil)
+}
+
+// AddPlayer registers a player for a guild. Adding the same PUUID twice
+// in one guild is a no-op and reports false.
+func (s *Store) AddPlayer(p *Player) (added bool, err error) {
+	res, err := s.db.Exec(`
+		INSERT OR IGNORE INTO players (guild_id, game_name, tag_line, puuid, summoner_id, platform, discord_user_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		p.GuildID, p.GameName, p.TagLine, p.PUUID, p.SummonerID, p.Platform, p.DiscordUserID, now())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil || n == 0 {
+		return false, err
+	}
+	p.ID, err = res.LastInsertId()
+	return true, err
+}
+
+// Players returns the guild's roster in registration order.
+func (s *Store) Players(guildID string) ([]Player, error) {
+	rows, err := s.db.Query(`
+		SELECT id, guild_id, game_name, tag_line, puuid, summoner_id, platform, discord_user_id, paused, paused_until, state, priority
+		FROM players WHERE guild_id = ? ORDER BY id`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPlayers(rows)
+}
+
+// AllPlayers returns every registered player across all guilds,
+// used by the worker's refresh cycle.
+func (s *Store) AllPlayers() ([]Player, error) {
+	rows, err := s.db.Query(`
+		SELECT id, guild_id, game_name, tag_line, puuid, summoner_id, platform, discord_user_id, paused, paused_until, state, priority
+		FROM players ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPlayers(rows)
+}
+
+// PlayerByRiotID looks a player up by gameName#tagLine within a guild.
+func (s *Store) PlayerByRiotID(guildID, gameName, tagLine string) (*Player, error) {
+	row := s.db.QueryRow(`
+		SELECT id, guild_id, game_name, tag_line, puuid, summoner_id, platform, discord_user_id, paused, paused_until, state, priority
+		FROM players
+		WHERE guild_id = ? AND game_name = ? COLLATE NOCASE AND tag_line = ? COLLATE NOCASE`,
+		guildID, gameName, tagLine)
+	var p Player
+	var pausedUntil int64
+	err := row.Scan(&p.ID, &p.GuildID, &p.GameName, &p.TagLine, &p.PUUID, &p.SummonerID, &p.Platform, &p.DiscordUserID, &p.Paused, &pausedUntil, &p.State, &p.Priority)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotRegistered
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pausedUntil > 0 {
+		p.PausedUntil = time.Unix(pausedUntil, 0)
+	}
+	return &p, nil
+}
+
+// PlayersByOwner returns all accounts a Discord user has linked in a
+// guild, in registration order.
+func (s *Store) PlayersByOwner(guildID, discordUserID string) ([]Player, error) {
+	rows, err := s.db.Query(`
+		SELECT id, guild_id, game_name, tag_line, puuid, summoner_id, platform, discord_user_id, paused, paused_until, state, priority
+		FROM players WHERE guild_id = ? AND discord_user_id = ? ORDER BY id`, guildID, discordUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPlayers(rows)
+}
+
+// SetPlayerOwner claims an account for a Discord user.
+func (s *Store) SetPlayerOwner(playerID int64, discordUserID string) error {
+	_, err := s.db.Exec(`UPDATE players SET discord_user_id = ? WHERE id = ?`, discordUserID, playerID)
+	return err
+}
+
+// SetPlayerPaused marks a player inactive (or active again); until
+// bounds the pause, with the zero time meaning until unpaused.
+func (s *Store) SetPlayerPaused(playerID int64, paused bool, until time.Time) error {
+	var untilUnix int64
+	if !until.IsZero() {
+		untilUnix = until.Unix()
+	}
+	_, err := s.db.Exec(`UPDATE players SET paused = ?, paused_until = ? WHERE id = ?`,
+		paused, untilUnix, playerID)
+	return err
+}
+
+// DeletePlayer removes a roster entry together with its snapshots, goal
+// and match links. Cached match payloads stay: other players may share
+// them, and maintenance prunes orphans by age anyway.
+func (s *Store) DeletePlayer(playerID int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range []string{
+		`DELETE FROM snapshots WHERE player_id = ?`,
+		`DELETE FROM player_matches WHERE player_id = ?`,
+		`DELETE FROM goals WHERE player_id = ?`,
+		`DELETE FROM players WHERE id = ?`,
+	} {
+		if _, err := tx.Exec(stmt, playerID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SetPlayerPriority marks (or unmarks) a player for the worker's
+// frequent-refresh pass.
+func (s *Store) SetPlayerPriority(playerID int64, priority bool) error {
+	_, err := s.db.Exec(`UPDATE players SET priority = ? WHERE id = ?`, priority, playerID)
+	return err
+}
+
+// SetPlayerState records an account's health state ("" clears it).
+func (s *Store) SetPlayerState(playerID int64, state string) error {
+	_, err := s.db.Exec(`UPDATE players SET state = ? WHERE id = ?`, state, playerID)
+	return err
+}
+
+func scanPlayers(rows *sql.Rows) ([]Player, error) {
+	var out []Player
+	for rows.Next() {
+		var p Player
+		var pausedUntil int64
+		if err := rows.Scan(&p.ID, &p.GuildID, &p.GameName, &p.TagLine, &p.PUUID, &p.SummonerID, &p.Platform, &p.DiscordUserID, &p.Paused, &pausedUntil, &p.State, &p.Priority); err != nil {
+			return nil, err
+		}
+		if pausedUntil > 0 {
+			p.PausedUntil = time.Unix(pausedUntil, 0)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
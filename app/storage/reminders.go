@@ -0,0 +1,71 @@
+package storage
+
+import "database/sql"
+
+// Reminder is a user's recurring DM reminder.
+type Reminder struct {
+	ID      int64
+	GuildID string
+	UserID  string
+	Cron    string
+	Message string
+}
+
+// AddReminder stores a reminder and fills in its ID.
+func (s *Store) AddReminder(r *Reminder) error {
+	res, err := s.db.Exec(`
+		INSERT INTO reminders (guild_id, user_id, cron, message, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		r.GuildID, r.UserID, r.Cron, r.Message, now())
+	if err != nil {
+		return err
+	}
+	r.ID, err = res.LastInsertId()
+	return err
+}
+
+// RemindersForUser returns a user's reminders in one guild.
+func (s *Store) RemindersForUser(guildID, userID string) ([]Reminder, error) {
+	rows, err := s.db.Query(`
+		SELECT id, guild_id, user_id, cron, message FROM reminders
+		WHERE guild_id = ? AND user_id = ? ORDER BY id`, guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanReminders(rows)
+}
+
+// AllReminders returns every reminder, for the worker's minutely sweep.
+func (s *Store) AllReminders() ([]Reminder, error) {
+	rows, err := s.db.Query(`
+		SELECT id, guild_id, user_id, cron, message FROM reminders ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanReminders(rows)
+}
+
+// DeleteReminder removes one of a user's reminders, reporting whether
+// anything was deleted.
+func (s *Store) DeleteReminder(id int64, userID string) (bool, error) {
+	res, err := s.db.Exec(`DELETE FROM reminders WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func scanReminders(rows *sql.Rows) ([]Reminder, error) {
+	var out []Reminder
+	for rows.Next() {
+		var r Reminder
+		if err := rows.Scan(&r.ID, &r.GuildID, &r.UserID, &r.Cron, &r.Message); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
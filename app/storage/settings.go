@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// Guild setting keys.
+const (
+	// SettingDailyPostCron is the per-guild cron expression for the
+	// daily ranking post.
+	SettingDailyPostCron = "schedule.daily_post"
+	// SettingDailyRecapCron is the per-guild cron expression for the
+	// previous day's recap post.
+	SettingDailyRecapCron = "schedule.daily_recap"
+	// SettingOutputFormat is the guild default output format
+	// ("compact" or "detailed").
+	SettingOutputFormat = "output.format"
+	// SettingLocale is the guild display language ("ja" or "en").
+	SettingLocale = "locale"
+
+	// Channel overrides per announcement type; each falls back to the
+	// guild's default announcement channel when unset.
+	SettingChannelDaily      = "channel.daily"
+	SettingChannelRecap      = "channel.recap"
+	SettingChannelPromotions = "channel.promotions"
+	SettingChannelLive       = "channel.live"
+
+	// SettingPowerWeights is the JSON weight set for !powerranking.
+	SettingPowerWeights = "power.weights"
+
+	// SettingIntCheck enables the tongue-in-cheek !intcheck leaderboard
+	// ("on"/"off"; off by default).
+	SettingIntCheck = "fun.intcheck"
+
+	// SettingRecapVision includes the best/worst vision score line in
+	// the daily recap ("on"/"off"; on by default).
+	SettingRecapVision = "recap.vision"
+
+	// SettingGlobalOptIn shares the guild's roster with the cross-guild
+	// !globalranking leaderboard ("on"/"off"; off by default).
+	SettingGlobalOptIn = "global.optin"
+
+	// SettingRiotAPIKey is a guild-provided Riot API key; refreshes for
+	// that guild's roster spend its budget instead of the shared key's.
+	SettingRiotAPIKey = "riot.apikey"
+
+	// SettingRaceDays lists the guild's LP race days as comma-separated
+	// YYYYMMDD dates; on those days the worker runs a live race thread.
+	SettingRaceDays = "race.days"
+	// SettingRaceThread tracks the currently open race thread as
+	// "YYYYMMDD:threadID" so refreshes know where to post and the worker
+	// knows which thread to close at the next reset.
+	SettingRaceThread = "race.thread"
+
+	// SettingMentionPrefix prefixes per-user mention preferences: the key
+	// "mention.<discordUserID>" set to "off" stops recaps and promotion
+	// alerts from pinging that user. On by default.
+	SettingMentionPrefix = "mention."
+)
+
+// secretSettings lists the keys whose values are sensitive; they are
+// encrypted at rest when the store has a secrets box.
+var secretSettings = map[string]bool{
+	SettingRiotAPIKey: true,
+}
+
+// AllGuildSettings returns every stored setting for a guild.
+func (s *Store) AllGuildSettings(guildID string) (map[string]string, error) {
+	rows, err := s.db.Query(`
+		SELECT key, value FROM guild_settings WHERE guild_id = ?`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]string{}
+	for rows.Next() {
+		var k, v string
+		if err := rows.Scan(&k, &v); err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, rows.Err()
+}
+
+// SetGuildSetting stores one per-guild configuration value. Sensitive
+// values are sealed before they touch the database when encryption is
+// configured.
+func (s *Store) SetGuildSetting(guildID, key, value string) error {
+	if err := s.EnsureGuild(guildID); err != nil {
+		return err
+	}
+	if s.secrets != nil && secretSettings[key] && value != "" {
+		sealed, err := s.secrets.Encrypt(value)
+		if err != nil {
+			return err
+		}
+		value = sealed
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO guild_settings (guild_id, key, value) VALUES (?, ?, ?)
+		ON CONFLICT (guild_id, key) DO UPDATE SET value = excluded.value`,
+		guildID, key, value)
+	return err
+}
+
+// GuildSetting returns a per-guild configuration value, falling back to
+// the given default when the guild has not set one.
+func (s *Store) GuildSetting(guildID, key, fallback string) (string, error) {
+	var value string
+	err := s.db.QueryRow(`
+		SELECT value FROM guild_settings WHERE guild_id = ? AND key = ?`,
+		guildID, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fallback, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if s.secrets != nil && secretSettings[key] {
+		return s.secrets.Decrypt(value)
+	}
+	return value, nil
+}
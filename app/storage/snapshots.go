@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Snapshot is one recorded rank reading for a player.
+type Snapshot struct {
+	PlayerID int64
+	Queue    string
+	Tier     string
+	Division string
+	LP       int
+	Wins     int
+	Losses   int
+	TakenAt  time.Time
+}
+
+// SaveSnapshot records a rank reading taken by the worker.
+func (s *Store) SaveSnapshot(snap *Snapshot) error {
+	_, err := s.db.Exec(`
+		INSERT INTO snapshots (player_id, queue, tier, division, lp, wins, losses, taken_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		snap.PlayerID, snap.Queue, snap.Tier, snap.Division, snap.LP, snap.Wins, snap.Losses, now())
+	return err
+}
+
+// RankedPlayer joins a roster entry with its most recent snapshot.
+// Tier is empty when no snapshot has been taken yet.
+type RankedPlayer struct {
+	Player
+	Tier     string
+	Division string
+	LP       int
+	Wins     int
+	Losses   int
+	TakenAt  time.Time
+	// Trend compares recent form against the season winrate:
+	// "↗" hot, "↘" cold, "→" steady, "" not enough data.
+	Trend string
+	// LPDelta is the LP-equivalent change since the baseline snapshot
+	// AttachLPDeltas was given; LPDeltaKnown reports whether a baseline
+	// existed.
+	LPDelta      int
+	LPDeltaKnown bool
+}
+
+// LatestRanks returns the guild's roster with each player's newest
+// snapshot for the given queue attached.
+func (s *Store) LatestRanks(guildID, queue string) ([]RankedPlayer, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, p.guild_id, p.game_name, p.tag_line, p.puuid, p.summoner_id, p.platform, p.discord_user_id,
+		       p.paused, p.paused_until, p.state,
+		       COALESCE(sn.tier, ''), COALESCE(sn.division, ''), COALESCE(sn.lp, 0),
+		       COALESCE(sn.wins, 0), COALESCE(sn.losses, 0), COALESCE(sn.taken_at, 0)
+		FROM players p
+		LEFT JOIN snapshots sn ON sn.id = (
+			SELECT id FROM snapshots
+			WHERE player_id = p.id AND queue = ?
+			ORDER BY taken_at DESC, id DESC LIMIT 1
+		)
+		WHERE p.guild_id = ?
+		ORDER BY p.id`, queue, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []RankedPlayer
+	for rows.Next() {
+		var rp RankedPlayer
+		var takenAt, pausedUntil int64
+		if err := rows.Scan(&rp.ID, &rp.GuildID, &rp.GameName, &rp.TagLine, &rp.PUUID, &rp.SummonerID, &rp.Platform, &rp.DiscordUserID,
+			&rp.Paused, &pausedUntil, &rp.State,
+			&rp.Tier, &rp.Division, &rp.LP, &rp.Wins, &rp.Losses, &takenAt); err != nil {
+			return nil, err
+		}
+		if takenAt > 0 {
+			rp.TakenAt = time.Unix(takenAt, 0)
+		}
+		if pausedUntil > 0 {
+			rp.PausedUntil = time.Unix(pausedUntil, 0)
+		}
+		out = append(out, rp)
+	}
+	return out, rows.Err()
+}
+
+// GlobalRanks returns the rosters of every guild that opted into the
+// cross-guild leaderboard, with latest snapshots attached. Callers
+// dedupe shared accounts by PUUID.
+func (s *Store) GlobalRanks(queue string) ([]RankedPlayer, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, p.guild_id, p.game_name, p.tag_line, p.puuid, p.summoner_id, p.platform, p.discord_user_id,
+		       p.paused, p.paused_until, p.state,
+		       COALESCE(sn.tier, ''), COALESCE(sn.division, ''), COALESCE(sn.lp, 0),
+		       COALESCE(sn.wins, 0), COALESCE(sn.losses, 0), COALESCE(sn.taken_at, 0)
+		FROM players p
+		JOIN guild_settings gs ON gs.guild_id = p.guild_id AND gs.key = ? AND gs.value = 'on'
+		LEFT JOIN snapshots sn ON sn.id = (
+			SELECT id FROM snapshots
+			WHERE player_id = p.id AND queue = ?
+			ORDER BY taken_at DESC, id DESC LIMIT 1
+		)
+		ORDER BY p.id`, SettingGlobalOptIn, queue)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []RankedPlayer
+	for rows.Next() {
+		var rp RankedPlayer
+		var takenAt, pausedUntil int64
+		if err := rows.Scan(&rp.ID, &rp.GuildID, &rp.GameName, &rp.TagLine, &rp.PUUID, &rp.SummonerID, &rp.Platform, &rp.DiscordUserID,
+			&rp.Paused, &pausedUntil, &rp.State,
+			&rp.Tier, &rp.Division, &rp.LP, &rp.Wins, &rp.Losses, &takenAt); err != nil {
+			return nil, err
+		}
+		if takenAt > 0 {
+			rp.TakenAt = time.Unix(takenAt, 0)
+		}
+		if pausedUntil > 0 {
+			rp.PausedUntil = time.Unix(pausedUntil, 0)
+		}
+		out = append(out, rp)
+	}
+	return out, rows.Err()
+}
+
+// DailySnapshots returns the last snapshot of each JST day for a
+// player and queue since from, oldest first.
+func (s *Store) DailySnapshots(playerID int64, queue string, from time.Time) ([]Snapshot, error) {
+	rows, err := s.db.Query(`
+		SELECT tier, division, lp, wins, losses, taken_at FROM snapshots
+		WHERE id IN (
+			SELECT MAX(id) FROM snapshots
+			WHERE player_id = ? AND queue = ? AND taken_at >= ?
+			GROUP BY date(taken_at, 'unixepoch', '+9 hours')
+		)
+		ORDER BY taken_at`, playerID, queue, from.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Snapshot
+	for rows.Next() {
+		var snap Snapshot
+		var takenAt int64
+		if err := rows.Scan(&snap.Tier, &snap.Division, &snap.LP, &snap.Wins, &snap.Losses, &takenAt); err != nil {
+			return nil, err
+		}
+		snap.PlayerID = playerID
+		snap.Queue = queue
+		snap.TakenAt = time.Unix(takenAt, 0)
+		out = append(out, snap)
+	}
+	return out, rows.Err()
+}
+
+// latestSnapshotRow is shared by the single-player lookups.
+func (s *Store) latestSnapshotRow(playerID int64, queue string) *sql.Row {
+	return s.db.QueryRow(`
+		SELECT tier, division, lp, wins, losses, taken_at FROM snapshots
+		WHERE player_id = ? AND queue = ?
+		ORDER BY taken_at DESC, id DESC LIMIT 1`, playerID, queue)
+}
+
+// LatestSnapshot returns the newest snapshot for one player, or nil if
+// none has been taken.
+func (s *Store) LatestSnapshot(playerID int64, queue string) (*Snapshot, error) {
+	var snap Snapshot
+	var takenAt int64
+	err := s.latestSnapshotRow(playerID, queue).
+		Scan(&snap.Tier, &snap.Division, &snap.LP, &snap.Wins, &snap.Losses, &takenAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snap.PlayerID = playerID
+	snap.Queue = queue
+	snap.TakenAt = time.Unix(takenAt, 0)
+	return &snap, nil
+}
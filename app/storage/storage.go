@@ -0,0 +1,231 @@
+// Package storage is the SQLite persistence layer shared by the gateway
+// and worker processes. It holds the player rosters, rank snapshots, the
+// match cache and the task queue the two processes communicate through.
+// Rosters and settings are keyed by Discord guild ID — every guild the
+// bot joins gets its own independent player list — so helpers take a
+// guildID unless they are explicitly cross-guild (AllPlayers, app
+// settings).
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/keny-N/lol-ranking/app/secrets"
+)
+
+// Store wraps the SQLite database.
+type Store struct {
+	db *sql.DB
+	// secrets, when set, seals sensitive setting values at rest.
+	secrets *secrets.Box
+}
+
+// UseSecrets enables at-rest encryption of sensitive values. Values
+// stored before the box was configured read back unchanged.
+func (s *Store) UseSecrets(box *secrets.Box) { s.secrets = box }
+
+// Open opens (and if necessary creates) the database at path and runs
+// any pending schema migrations.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	// Both processes share the file; WAL allows concurrent reads while
+	// one of them writes, but serialize writes through a single conn.
+	db.SetMaxOpenConns(1)
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+	return s, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error { return s.db.Close() }
+
+// GuildDataVersion is a cheap stamp that changes whenever data a
+// rendered reply could depend on changes for a guild: roster, rank
+// snapshots, cached matches or guild settings. Callers compare stamps,
+// never parse them.
+func (s *Store) GuildDataVersion(guildID string) (string, error) {
+	var version string
+	err := s.db.QueryRow(`
+		SELECT (SELECT COALESCE(MAX(id), 0) || '-' || COUNT(*) FROM players WHERE guild_id = ?1)
+		    || ':' || (SELECT COALESCE(MAX(sn.id), 0) FROM snapshots sn
+		               JOIN players p ON p.id = sn.player_id WHERE p.guild_id = ?1)
+		    || ':' || (SELECT COALESCE(MAX(pm.rowid), 0) FROM player_matches pm
+		               JOIN players p ON p.id = pm.player_id WHERE p.guild_id = ?1)
+		    || ':' || (SELECT COALESCE(MAX(rowid), 0) FROM guild_settings WHERE guild_id = ?1)`,
+		guildID).Scan(&version)
+	return version, err
+}
+
+// migrations are applied in order; the index in this slice plus one is
+// the schema version recorded in schema_version.
+var migrations = []string{
+	`
+	CREATE TABLE guilds (
+		guild_id            TEXT PRIMARY KEY,
+		announce_channel_id TEXT NOT NULL DEFAULT '',
+		created_at          INTEGER NOT NULL
+	);
+	CREATE TABLE players (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		guild_id    TEXT NOT NULL,
+		game_name   TEXT NOT NULL,
+		tag_line    TEXT NOT NULL,
+		puuid       TEXT NOT NULL,
+		summoner_id TEXT NOT NULL,
+		platform    TEXT NOT NULL,
+		created_at  INTEGER NOT NULL,
+		UNIQUE (guild_id, puuid)
+	);
+	CREATE TABLE snapshots (
+		id        INTEGER PRIMARY KEY AUTOINCREMENT,
+		player_id INTEGER NOT NULL REFERENCES players (id),
+		queue     TEXT NOT NULL,
+		tier      TEXT NOT NULL,
+		division  TEXT NOT NULL,
+		lp        INTEGER NOT NULL,
+		wins      INTEGER NOT NULL,
+		losses    INTEGER NOT NULL,
+		taken_at  INTEGER NOT NULL
+	);
+	CREATE INDEX idx_snapshots_player ON snapshots (player_id, taken_at);
+	CREATE TABLE matches (
+		match_id   TEXT PRIMARY KEY,
+		queue_id   INTEGER NOT NULL,
+		game_end   INTEGER NOT NULL,
+		payload    TEXT NOT NULL,
+		fetched_at INTEGER NOT NULL
+	);
+	CREATE TABLE player_matches (
+		player_id INTEGER NOT NULL REFERENCES players (id),
+		match_id  TEXT NOT NULL REFERENCES matches (match_id),
+		PRIMARY KEY (player_id, match_id)
+	);
+	CREATE TABLE tasks (
+		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind       TEXT NOT NULL,
+		payload    TEXT NOT NULL DEFAULT '',
+		status     TEXT NOT NULL DEFAULT 'pending',
+		attempts   INTEGER NOT NULL DEFAULT 0,
+		run_after  INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL
+	);
+	`,
+	`
+	CREATE TABLE guild_settings (
+		guild_id TEXT NOT NULL,
+		key      TEXT NOT NULL,
+		value    TEXT NOT NULL,
+		PRIMARY KEY (guild_id, key)
+	);
+	`,
+	`
+	CREATE TABLE processed_messages (
+		message_id TEXT PRIMARY KEY,
+		seen_at    INTEGER NOT NULL
+	);
+	`,
+	`
+	ALTER TABLE players ADD COLUMN discord_user_id TEXT NOT NULL DEFAULT '';
+	`,
+	`
+	CREATE TABLE goals (
+		player_id   INTEGER NOT NULL REFERENCES players (id),
+		season      TEXT NOT NULL,
+		tier        TEXT NOT NULL,
+		division    TEXT NOT NULL,
+		achieved_at INTEGER NOT NULL DEFAULT 0,
+		created_at  INTEGER NOT NULL,
+		PRIMARY KEY (player_id, season)
+	);
+	`,
+	`
+	CREATE TABLE reminders (
+		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+		guild_id   TEXT NOT NULL,
+		user_id    TEXT NOT NULL,
+		cron       TEXT NOT NULL,
+		message    TEXT NOT NULL,
+		created_at INTEGER NOT NULL
+	);
+	`,
+	`
+	ALTER TABLE players ADD COLUMN paused INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE players ADD COLUMN paused_until INTEGER NOT NULL DEFAULT 0;
+	`,
+	`
+	ALTER TABLE players ADD COLUMN state TEXT NOT NULL DEFAULT '';
+	`,
+	`
+	CREATE TABLE interaction_states (
+		message_id TEXT PRIMARY KEY,
+		guild_id   TEXT NOT NULL,
+		kind       TEXT NOT NULL,
+		payload    TEXT NOT NULL,
+		created_at INTEGER NOT NULL
+	);
+	`,
+	`
+	CREATE TABLE app_settings (
+		key   TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+	`,
+	`
+	CREATE INDEX idx_players_guild ON players (guild_id);
+	CREATE INDEX idx_matches_game_end ON matches (game_end);
+	`,
+	`
+	CREATE TABLE api_tokens (
+		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+		guild_id   TEXT NOT NULL,
+		name       TEXT NOT NULL,
+		token_hash TEXT NOT NULL UNIQUE,
+		scopes     TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		UNIQUE (guild_id, name)
+	);
+	`,
+	`
+	ALTER TABLE players ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+	`,
+}
+
+func (s *Store) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return err
+	}
+	var version int
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version); err != nil {
+		return err
+	}
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_version (version) VALUES (?)`, i+1); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func now() int64 { return time.Now().Unix() }
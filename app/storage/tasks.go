@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Task kinds the gateway enqueues for the worker.
+const (
+	// TaskRefreshPlayer asks the worker to fetch fresh league data and
+	// backfill matches for one player; the payload is the player ID.
+	TaskRefreshPlayer = "refresh_player"
+	// TaskPostDaily asks the worker to post the daily ranking now;
+	// the payload is the guild ID.
+	TaskPostDaily = "post_daily"
+	// TaskPostRecap asks the worker to post yesterday's recap now;
+	// the payload is the guild ID.
+	TaskPostRecap = "post_recap"
+	// TaskFlexPing pings a flex-night poll's confirmed participants at
+	// the planned time; the payload is JSON with the poll's message and
+	// channel IDs.
+	TaskFlexPing = "flex_ping"
+)
+
+// Task is one unit of work handed from the gateway to the worker
+// through the shared database.
+type Task struct {
+	ID      int64
+	Kind    string
+	Payload string
+}
+
+// EnqueueTask adds a task for the worker to pick up.
+func (s *Store) EnqueueTask(kind, payload string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO tasks (kind, payload, created_at) VALUES (?, ?, ?)`,
+		kind, payload, now())
+	return err
+}
+
+// EnqueueTaskAt adds a task that only becomes runnable at runAfter.
+func (s *Store) EnqueueTaskAt(kind, payload string, runAfter time.Time) error {
+	_, err := s.db.Exec(`
+		INSERT INTO tasks (kind, payload, created_at, run_after) VALUES (?, ?, ?, ?)`,
+		kind, payload, now(), runAfter.Unix())
+	return err
+}
+
+// NextTask claims the oldest runnable pending task. It returns nil when
+// the queue is empty.
+func (s *Store) NextTask() (*Task, error) {
+	var t Task
+	err := s.db.QueryRow(`
+		SELECT id, kind, payload FROM tasks
+		WHERE status = 'pending' AND run_after <= ?
+		ORDER BY id LIMIT 1`, now()).Scan(&t.ID, &t.Kind, &t.Payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Exec(`
+		UPDATE tasks SET status = 'running', attempts = attempts + 1 WHERE id = ?`, t.ID); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// FinishTask marks a task done.
+func (s *Store) FinishTask(id int64) error {
+	_, err := s.db.Exec(`UPDATE tasks SET status = 'done' WHERE id = ?`, id)
+	return err
+}
+
+// FailTask reschedules a failed task with a delay, or marks it failed
+// for good after three attempts.
+func (s *Store) FailTask(id int64) error {
+	_, err := s.db.Exec(`
+		UPDATE tasks SET
+			status = CASE WHEN attempts >= 3 THEN 'failed' ELSE 'pending' END,
+			run_after = ?
+		WHERE id = ?`, time.Now().Add(time.Minute).Unix(), id)
+	return err
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// trendSampleSize is how many recent solo-queue games define "current
+// form"; trendMinGames is the minimum before an arrow is shown.
+const (
+	trendSampleSize = 20
+	trendMinGames   = 8
+	// trendMargin is the winrate gap (in percent points) separating
+	// hot/cold form from steady.
+	trendMargin = 5.0
+)
+
+// RecentSoloForm counts wins over the player's most recent cached
+// solo-queue games, up to sample of them.
+func (s *Store) RecentSoloForm(p *Player, sample int) (wins, games int, err error) {
+	matches, err := s.RecentMatchesForPlayer(p.ID, sample)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, m := range matches {
+		if m.Info.QueueID != riotapi.QueueIDSolo {
+			continue
+		}
+		for _, part := range m.Info.Participants {
+			if part.PUUID == p.PUUID {
+				games++
+				if part.Win {
+					wins++
+				}
+				break
+			}
+		}
+	}
+	return wins, games, nil
+}
+
+// AttachTrends fills each RankedPlayer's Trend arrow by comparing the
+// winrate of their recent cached solo-queue games against their season
+// winrate from the snapshot.
+func (s *Store) AttachTrends(players []RankedPlayer) error {
+	for i := range players {
+		p := &players[i]
+		if p.Wins+p.Losses == 0 {
+			continue
+		}
+		wins, games, err := s.RecentSoloForm(&p.Player, trendSampleSize)
+		if err != nil {
+			return err
+		}
+		if games < trendMinGames {
+			continue
+		}
+		recent := float64(wins) / float64(games) * 100
+		season := float64(p.Wins) / float64(p.Wins+p.Losses) * 100
+		switch {
+		case recent > season+trendMargin:
+			p.Trend = "↗"
+		case recent < season-trendMargin:
+			p.Trend = "↘"
+		default:
+			p.Trend = "→"
+		}
+	}
+	return nil
+}
+
+// AttachLPDeltas fills each RankedPlayer's LP change against their last
+// snapshot taken before since (typically the start of the display day).
+// Tier and division changes are folded in as LP equivalents, so a
+// promotion shows as a large positive delta rather than wrapping.
+func (s *Store) AttachLPDeltas(players []RankedPlayer, queue string, since time.Time) error {
+	for i := range players {
+		p := &players[i]
+		if p.Tier == "" {
+			continue
+		}
+		var tier, division string
+		var lp int
+		err := s.db.QueryRow(`
+			SELECT tier, division, lp FROM snapshots
+			WHERE player_id = ? AND queue = ? AND taken_at < ?
+			ORDER BY taken_at DESC, id DESC LIMIT 1`,
+			p.ID, queue, since.Unix()).Scan(&tier, &division, &lp)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		p.LPDelta = rank.Value(p.Tier, p.Division, p.LP) - rank.Value(tier, division, lp)
+		p.LPDeltaKnown = true
+	}
+	return nil
+}
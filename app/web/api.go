@@ -0,0 +1,191 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// apiVersion is the current public API version. Breaking changes get a
+// new path prefix; v1 responses only ever gain fields.
+const apiVersion = "v1"
+
+// apiPlayer is one registered player in API responses.
+type apiPlayer struct {
+	GameName string `json:"game_name"`
+	TagLine  string `json:"tag_line"`
+	Platform string `json:"platform"`
+}
+
+// apiRankingRow is one leaderboard entry in API responses.
+type apiRankingRow struct {
+	Place    int    `json:"place"`
+	GameName string `json:"game_name"`
+	TagLine  string `json:"tag_line"`
+	Tier     string `json:"tier"`
+	Division string `json:"division"`
+	LP       int    `json:"lp"`
+	Wins     int    `json:"wins"`
+	Losses   int    `json:"losses"`
+	Rank     string `json:"rank"` // formatted, e.g. "ゴールド II 45LP"
+}
+
+// handleAPI routes /api/v1/guilds/{guild}/{resource}. The resources are
+// read-only views over stored data, mirroring what the chat commands
+// show.
+func (s *Server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/openapi.json" {
+		s.handleOpenAPI(w, r)
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	// api / v1 / guilds / {guild} / {resource}
+	if len(parts) != 5 || parts[1] != apiVersion || parts[2] != "guilds" {
+		http.NotFound(w, r)
+		return
+	}
+	guildID, resource := parts[3], parts[4]
+	known, err := s.store.GuildKnown(guildID)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !known {
+		http.NotFound(w, r)
+		return
+	}
+	scopes := map[string]string{
+		"ranking": storage.ScopeRanking,
+		"players": storage.ScopeStats,
+	}
+	scope, ok := scopes[resource]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if !s.authorize(w, r, guildID, scope) {
+		return
+	}
+	switch resource {
+	case "ranking":
+		s.apiRanking(w, r, guildID)
+	case "players":
+		s.apiPlayers(w, guildID)
+	}
+}
+
+// bearerToken extracts the presented API token: the Authorization
+// header first, then ?token= for clients that cannot set headers.
+func bearerToken(r *http.Request) string {
+	if v, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return v
+	}
+	return r.URL.Query().Get("token")
+}
+
+// authorize enforces the API token check for one guild-scoped endpoint,
+// writing the error response itself when the check fails. Token checks
+// are opt-in per guild: a guild without issued tokens keeps its
+// endpoints open, matching the pre-token behavior.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, guildID, scope string) bool {
+	issued, err := s.store.APITokens(guildID)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return false
+	}
+	if len(issued) == 0 {
+		return true
+	}
+	token := bearerToken(r)
+	if token == "" {
+		http.Error(w, "api token required", http.StatusUnauthorized)
+		return false
+	}
+	t, err := s.store.APITokenByToken(token)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return false
+	}
+	if t == nil || t.GuildID != guildID {
+		http.Error(w, "invalid api token", http.StatusUnauthorized)
+		return false
+	}
+	if !t.HasScope(scope) {
+		http.Error(w, "token lacks the "+scope+" scope", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// apiQueue resolves the ?queue= parameter (solo by default).
+func apiQueue(r *http.Request) (string, bool) {
+	switch r.URL.Query().Get("queue") {
+	case "", "solo":
+		return riotapi.QueueSolo, true
+	case "flex":
+		return riotapi.QueueFlex, true
+	}
+	return "", false
+}
+
+func (s *Server) apiRanking(w http.ResponseWriter, r *http.Request, guildID string) {
+	queue, ok := apiQueue(r)
+	if !ok {
+		http.Error(w, "queue must be solo or flex", http.StatusBadRequest)
+		return
+	}
+	players, err := s.store.LatestRanks(guildID, queue)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	rows := []apiRankingRow{}
+	for i := range players {
+		if players[i].IsPaused(now) {
+			continue
+		}
+		rows = append(rows, apiRankingRow{
+			Place:    len(rows) + 1,
+			GameName: players[i].GameName,
+			TagLine:  players[i].TagLine,
+			Tier:     players[i].Tier,
+			Division: players[i].Division,
+			LP:       players[i].LP,
+			Wins:     players[i].Wins,
+			Losses:   players[i].Losses,
+			Rank:     rank.Format(players[i].Tier, players[i].Division, players[i].LP),
+		})
+	}
+	writeJSON(w, rows)
+}
+
+func (s *Server) apiPlayers(w http.ResponseWriter, guildID string) {
+	players, err := s.store.Players(guildID)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	out := []apiPlayer{}
+	for i := range players {
+		out = append(out, apiPlayer{
+			GameName: players[i].GameName,
+			TagLine:  players[i].TagLine,
+			Platform: players[i].Platform,
+		})
+	}
+	writeJSON(w, out)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("web: encode api response: %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package web
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// feedWindowDays is how far back the Atom feed reaches.
+const feedWindowDays = 14
+
+// atomFeed and atomEntry model the subset of Atom the feed needs.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string `xml:"title"`
+	ID      string `xml:"id"`
+	Updated string `xml:"updated"`
+	Content string `xml:"content"`
+
+	at time.Time // sort key, not serialized
+}
+
+// handleFeed serves /feed/{guild}.atom: tier changes and daily recap
+// summaries from the last feedWindowDays days, newest first.
+func (s *Server) handleFeed(w http.ResponseWriter, r *http.Request) {
+	guildID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/feed/"), ".atom")
+	if guildID == "" || strings.Contains(guildID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	known, err := s.store.GuildKnown(guildID)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !known {
+		http.NotFound(w, r)
+		return
+	}
+
+	from := time.Now().AddDate(0, 0, -feedWindowDays)
+	entries, err := s.feedEntries(guildID, from)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].at.After(entries[j].at) })
+
+	updated := time.Now()
+	if len(entries) > 0 {
+		updated = entries[0].at
+	}
+	feed := atomFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		Title:   "LoLランキング",
+		ID:      "tag:lol-ranking," + guildID,
+		Updated: updated.Format(time.RFC3339),
+		Entries: entries,
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(feed)
+}
+
+// feedEntries collects tier changes per player and one summary per
+// played day from the snapshot and match history.
+func (s *Server) feedEntries(guildID string, from time.Time) ([]atomEntry, error) {
+	players, err := s.store.Players(guildID)
+	if err != nil {
+		return nil, err
+	}
+	var entries []atomEntry
+	for i := range players {
+		snaps, err := s.store.DailySnapshots(players[i].ID, riotapi.QueueSolo, from)
+		if err != nil {
+			return nil, err
+		}
+		for j := 1; j < len(snaps); j++ {
+			prev, cur := &snaps[j-1], &snaps[j]
+			if prev.Tier == cur.Tier && prev.Division == cur.Division {
+				continue
+			}
+			verb := "昇格"
+			if rank.Value(cur.Tier, cur.Division, cur.LP) < rank.Value(prev.Tier, prev.Division, prev.LP) {
+				verb = "降格"
+			}
+			entries = append(entries, atomEntry{
+				Title: fmt.Sprintf("%s が %s に%s", players[i].RiotID(),
+					rank.Format(cur.Tier, cur.Division, cur.LP), verb),
+				ID: fmt.Sprintf("tag:lol-ranking,%s:player-%d:%s", guildID,
+					players[i].ID, cur.TakenAt.Format("2006-01-02")),
+				Updated: cur.TakenAt.Format(time.RFC3339),
+				Content: fmt.Sprintf("%s → %s", rank.Format(prev.Tier, prev.Division, prev.LP),
+					rank.Format(cur.Tier, cur.Division, cur.LP)),
+				at: cur.TakenAt,
+			})
+		}
+	}
+	// One recap summary per day with games.
+	for day := 0; day < feedWindowDays; day++ {
+		d := time.Now().In(render.JST).AddDate(0, 0, -day-1)
+		start := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, render.JST)
+		recap, err := s.store.RecapData(guildID, start, start.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+		games, wins, active := 0, 0, 0
+		for _, pm := range recap {
+			if len(pm.Matches) == 0 {
+				continue
+			}
+			active++
+			for _, m := range pm.Matches {
+				games++
+				for _, part := range m.Info.Participants {
+					if part.PUUID == pm.PUUID && part.Win {
+						wins++
+					}
+				}
+			}
+		}
+		if games == 0 {
+			continue
+		}
+		at := start.AddDate(0, 0, 1)
+		entries = append(entries, atomEntry{
+			Title: fmt.Sprintf("%s の戦績: %d人で%d試合", start.Format("1/2"), active, games),
+			ID: fmt.Sprintf("tag:lol-ranking,%s:recap:%s", guildID,
+				start.Format("2006-01-02")),
+			Updated: at.Format(time.RFC3339),
+			Content: fmt.Sprintf("%d勝%d敗", wins, games-wins),
+			at:      at,
+		})
+	}
+	return entries, nil
+}
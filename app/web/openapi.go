@@ -0,0 +1,101 @@
+package web
+
+import "net/http"
+
+// apiRoutes describes the versioned API surface once; the OpenAPI
+// document is generated from it at request time so the spec cannot
+// drift from the route list.
+var apiRoutes = []struct {
+	path    string
+	summary string
+	queue   bool // accepts the ?queue= parameter
+	schema  string
+}{
+	{
+		path:    "/api/" + apiVersion + "/guilds/{guild}/ranking",
+		summary: "Current leaderboard for a guild, best rank first.",
+		queue:   true,
+		schema:  "RankingRow",
+	},
+	{
+		path:    "/api/" + apiVersion + "/guilds/{guild}/players",
+		summary: "Registered players for a guild.",
+		schema:  "Player",
+	},
+}
+
+// openAPISchemas mirror the api*-struct JSON shapes.
+var openAPISchemas = map[string]interface{}{
+	"Player": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"game_name": map[string]string{"type": "string"},
+			"tag_line":  map[string]string{"type": "string"},
+			"platform":  map[string]string{"type": "string"},
+		},
+	},
+	"RankingRow": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"place":     map[string]string{"type": "integer"},
+			"game_name": map[string]string{"type": "string"},
+			"tag_line":  map[string]string{"type": "string"},
+			"tier":      map[string]string{"type": "string"},
+			"division":  map[string]string{"type": "string"},
+			"lp":        map[string]string{"type": "integer"},
+			"wins":      map[string]string{"type": "integer"},
+			"losses":    map[string]string{"type": "integer"},
+			"rank":      map[string]string{"type": "string"},
+		},
+	},
+}
+
+// handleOpenAPI serves /api/openapi.json, an OpenAPI 3 description of
+// the versioned API built from apiRoutes.
+func (s *Server) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	paths := map[string]interface{}{}
+	for _, route := range apiRoutes {
+		params := []interface{}{map[string]interface{}{
+			"name":     "guild",
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]string{"type": "string"},
+		}}
+		if route.queue {
+			params = append(params, map[string]interface{}{
+				"name":   "queue",
+				"in":     "query",
+				"schema": map[string]interface{}{"type": "string", "enum": []string{"solo", "flex"}},
+			})
+		}
+		paths[route.path] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":    route.summary,
+				"parameters": params,
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "OK",
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{
+									"type":  "array",
+									"items": map[string]string{"$ref": "#/components/schemas/" + route.schema},
+								},
+							},
+						},
+					},
+					"404": map[string]interface{}{"description": "unknown guild"},
+				},
+			},
+		}
+	}
+	writeJSON(w, map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]string{
+			"title":   "lol-ranking data API",
+			"version": apiVersion,
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": openAPISchemas},
+	})
+}
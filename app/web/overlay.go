@@ -0,0 +1,90 @@
+package web
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// overlayRows caps how many players a browser-source overlay shows.
+const overlayRows = 10
+
+// overlayTemplate is styled for OBS browser sources: transparent
+// background, readable over gameplay, and self-refreshing.
+var overlayTemplate = template.Must(template.New("overlay").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="60">
+<style>
+  body { background: transparent; margin: 0; font-family: "Hiragino Kaku Gothic ProN", "Noto Sans JP", sans-serif; }
+  ul { list-style: none; margin: 0; padding: 8px; }
+  li { color: #fff; text-shadow: 0 0 4px #000, 0 1px 2px #000; font-size: 20px; line-height: 1.5; }
+  .rank { display: inline-block; width: 2em; color: #ffd76a; }
+  .tier { opacity: .85; margin-left: .5em; }
+</style>
+</head>
+<body>
+<ul>
+{{- range .Rows}}
+  <li><span class="rank">{{.Place}}.</span>{{.Name}}<span class="tier">{{.Rank}}</span></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+type overlayRow struct {
+	Place int
+	Name  string
+	Rank  string
+}
+
+// handleOverlay serves /overlay/{guild}/ranking, the friend-group
+// leaderboard as an auto-refreshing transparent page.
+func (s *Server) handleOverlay(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "overlay" || parts[2] != "ranking" {
+		http.NotFound(w, r)
+		return
+	}
+	guildID := parts[1]
+	known, err := s.store.GuildKnown(guildID)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !known {
+		http.NotFound(w, r)
+		return
+	}
+	players, err := s.store.LatestRanks(guildID, riotapi.QueueSolo)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	var rows []overlayRow
+	for i := range players {
+		if players[i].IsPaused(now) {
+			continue
+		}
+		rows = append(rows, overlayRow{
+			Place: len(rows) + 1,
+			Name:  players[i].RiotID(),
+			Rank:  rank.Format(players[i].Tier, players[i].Division, players[i].LP),
+		})
+		if len(rows) == overlayRows {
+			break
+		}
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := overlayTemplate.Execute(w, struct{ Rows []overlayRow }{rows}); err != nil {
+		log.Printf("web: render overlay for %s: %v", guildID, err)
+	}
+}
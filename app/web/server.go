@@ -0,0 +1,154 @@
+// Package web is the worker's optional HTTP side: a WebSocket endpoint
+// (/ws) that pushes bot events — rank refreshes, promotions, goal
+// celebrations — as JSON, read-only views such as the OBS ranking
+// overlay (/overlay/{guild}/ranking), and a versioned JSON data API
+// (/api/v1, described by /api/openapi.json). The server only runs when
+// HTTP_ADDR is set.
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// Event is one pushed message. Data is event-specific and kept flat so
+// overlay scripts can consume it without a schema.
+type Event struct {
+	Type    string      `json:"type"`
+	GuildID string      `json:"guild_id,omitempty"`
+	At      time.Time   `json:"at"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// clientBuffer is how many events a slow client may fall behind before
+// it starts missing them.
+const clientBuffer = 16
+
+// Server fans bot events out to WebSocket subscribers and serves the
+// read-only web views (stream overlays).
+type Server struct {
+	httpServer *http.Server
+	store      *storage.Store
+	secret     string // signs share links; empty disables /share
+
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+}
+
+// upgrader accepts any origin: overlays are typically loaded from
+// file:// or localhost and the stream carries no secrets.
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// New builds a Server listening on addr once started, reading display
+// data from store. secret signs public share links; pass "" to leave
+// /share disabled.
+func New(addr string, store *storage.Store, secret string) *Server {
+	s := &Server{store: store, secret: secret, clients: make(map[chan []byte]struct{})}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", s.handleWS)
+	mux.HandleFunc("/overlay/", s.handleOverlay)
+	mux.HandleFunc("/share/ranking", s.handleShare)
+	mux.HandleFunc("/feed/", s.handleFeed)
+	mux.HandleFunc("/api/", s.handleAPI)
+	s.httpServer = &http.Server{Addr: addr, Handler: mux}
+	return s
+}
+
+// Start begins serving in the background.
+func (s *Server) Start() {
+	go func() {
+		log.Printf("web: listening on %s", s.httpServer.Addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("web: serve: %v", err)
+		}
+	}()
+}
+
+// Stop closes the listener and all client connections.
+func (s *Server) Stop() {
+	if err := s.httpServer.Close(); err != nil {
+		log.Printf("web: close: %v", err)
+	}
+}
+
+// Publish pushes one event to every connected client. Clients that have
+// fallen clientBuffer events behind miss it rather than block the bot.
+func (s *Server) Publish(e Event) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("web: marshal event %s: %v", e.Type, err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
+
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	// The event stream is cross-guild, so any issued token grants it;
+	// until the first token exists it stays open as before.
+	if locked, err := s.store.AnyAPITokens(); err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	} else if locked {
+		t, err := s.store.APITokenByToken(bearerToken(r))
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if t == nil {
+			http.Error(w, "invalid api token", http.StatusUnauthorized)
+			return
+		}
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("web: upgrade: %v", err)
+		return
+	}
+	ch := make(chan []byte, clientBuffer)
+	s.mu.Lock()
+	s.clients[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	// The read loop only serves to notice the client going away.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		case payload := <-ch:
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+)
+
+// shareSig signs a share link's guild and expiry with the shared
+// secret, so the public URL cannot be forged or extended.
+func shareSig(secret, guildID string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s|%d", guildID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ShareURL builds a signed, expiring public leaderboard URL. The
+// gateway calls this when answering !share; the worker's HTTP server
+// verifies it.
+func ShareURL(baseURL, secret, guildID string, expires time.Time) string {
+	e := expires.Unix()
+	q := url.Values{}
+	q.Set("g", guildID)
+	q.Set("e", strconv.FormatInt(e, 10))
+	q.Set("sig", shareSig(secret, guildID, e))
+	return baseURL + "/share/ranking?" + q.Encode()
+}
+
+// shareTemplate is the read-only public leaderboard page.
+var shareTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>ランキング</title>
+<style>
+  body { background: #1b1e23; color: #e8e8e8; font-family: "Hiragino Kaku Gothic ProN", "Noto Sans JP", sans-serif; margin: 0 auto; max-width: 32em; padding: 2em 1em; }
+  h1 { font-size: 1.3em; }
+  ol { padding-left: 2em; }
+  li { line-height: 1.8; }
+  .tier { color: #ffd76a; margin-left: .5em; }
+  footer { margin-top: 2em; font-size: .8em; opacity: .6; }
+</style>
+</head>
+<body>
+<h1>ソロランクランキング</h1>
+<ol>
+{{- range .Rows}}
+  <li>{{.Name}}<span class="tier">{{.Rank}}</span></li>
+{{- end}}
+</ol>
+<footer>{{.AsOf}} 時点 ・ このリンクは {{.Expires}} まで有効です</footer>
+</body>
+</html>
+`))
+
+// handleShare serves /share/ranking after verifying the signature and
+// expiry baked into the URL.
+func (s *Server) handleShare(w http.ResponseWriter, r *http.Request) {
+	if s.secret == "" {
+		http.NotFound(w, r)
+		return
+	}
+	guildID := r.URL.Query().Get("g")
+	expires, err := strconv.ParseInt(r.URL.Query().Get("e"), 10, 64)
+	if err != nil || guildID == "" {
+		http.Error(w, "invalid link", http.StatusBadRequest)
+		return
+	}
+	sig := r.URL.Query().Get("sig")
+	if !hmac.Equal([]byte(sig), []byte(shareSig(s.secret, guildID, expires))) {
+		http.Error(w, "invalid link", http.StatusForbidden)
+		return
+	}
+	if time.Now().Unix() > expires {
+		http.Error(w, "このリンクは期限切れです", http.StatusGone)
+		return
+	}
+	players, err := s.store.LatestRanks(guildID, riotapi.QueueSolo)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	var rows []overlayRow
+	for i := range players {
+		if players[i].IsPaused(now) {
+			continue
+		}
+		rows = append(rows, overlayRow{
+			Name: players[i].RiotID(),
+			Rank: rank.Format(players[i].Tier, players[i].Division, players[i].LP),
+		})
+	}
+	data := struct {
+		Rows    []overlayRow
+		AsOf    string
+		Expires string
+	}{
+		Rows:    rows,
+		AsOf:    now.In(render.JST).Format("2006/01/02 15:04"),
+		Expires: time.Unix(expires, 0).In(render.JST).Format("2006/01/02 15:04"),
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := shareTemplate.Execute(w, data); err != nil {
+		log.Printf("web: render share for %s: %v", guildID, err)
+	}
+}
@@ -0,0 +1,174 @@
+package worker
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+// raceResetHour is when an LP race day begins and ends (JST) — the same
+// 5AM boundary the daily ranking uses as its reset.
+const raceResetHour = 5
+
+// raceResetDay returns the date of the most recent reset, which is what
+// a running race is keyed by: between midnight and 5AM the race opened
+// the previous calendar day is still live.
+func raceResetDay(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, render.JST)
+	if now.Hour() < raceResetHour {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// runRaceDay drives one guild's race thread lifecycle. It runs every
+// minute: a thread left over from a previous reset period is closed
+// with final standings, and if the current period is a configured race
+// day with no thread yet, one is opened. Keying on the reset period
+// rather than the exact 5AM minute means a missed tick self-heals on
+// the next one.
+func (w *Worker) runRaceDay(guildID string, now time.Time) {
+	day := raceResetDay(now).Format("20060102")
+	stored, err := w.store.GuildSetting(guildID, storage.SettingRaceThread, "")
+	if err != nil {
+		log.Printf("worker: race thread setting for %s: %v", guildID, err)
+		return
+	}
+	if openDay, threadID, ok := strings.Cut(stored, ":"); ok && openDay != day {
+		w.finishRaceThread(guildID, openDay, threadID)
+		stored = ""
+	}
+	if stored != "" {
+		return // today's thread is already open
+	}
+	days, err := w.store.GuildSetting(guildID, storage.SettingRaceDays, "")
+	if err != nil {
+		log.Printf("worker: race days for %s: %v", guildID, err)
+		return
+	}
+	for _, d := range strings.Split(days, ",") {
+		if d == day {
+			w.openRaceThread(guildID, day)
+			return
+		}
+	}
+}
+
+// openRaceThread starts the race day's thread in the daily announcement
+// channel and records it so refreshes know where to post.
+func (w *Worker) openRaceThread(guildID, day string) {
+	channelID, err := w.announceChannel(guildID, storage.SettingChannelDaily)
+	if err != nil {
+		log.Printf("worker: announce channel for %s: %v", guildID, err)
+		return
+	}
+	if channelID == "" {
+		return
+	}
+	date, err := time.ParseInLocation("20060102", day, render.JST)
+	if err != nil {
+		log.Printf("worker: race day %q for %s: %v", day, guildID, err)
+		return
+	}
+	thread, err := w.discord.ThreadStart(channelID,
+		"🏁 LPレース "+date.Format("1/2"), discordgo.ChannelTypeGuildPublicThread, 1440)
+	if err != nil {
+		log.Printf("worker: start race thread for %s: %v", guildID, err)
+		return
+	}
+	if _, err := w.discord.ChannelMessageSend(thread.ID,
+		"🏁 今日はLPレースデーです！次のリセット（朝5時）までのLP増減をここで実況します。最終順位もこのスレッドで発表します。"); err != nil {
+		log.Printf("worker: race thread intro for %s: %v", guildID, err)
+	}
+	if err := w.store.SetGuildSetting(guildID, storage.SettingRaceThread, day+":"+thread.ID); err != nil {
+		log.Printf("worker: save race thread for %s: %v", guildID, err)
+	}
+}
+
+// finishRaceThread posts the race's final standings — the roster sorted
+// by LP gained since the race opened — then archives the thread and
+// clears the tracking setting.
+func (w *Worker) finishRaceThread(guildID, day, threadID string) {
+	defer func() {
+		if err := w.store.SetGuildSetting(guildID, storage.SettingRaceThread, ""); err != nil {
+			log.Printf("worker: clear race thread for %s: %v", guildID, err)
+		}
+	}()
+	date, err := time.ParseInLocation("20060102", day, render.JST)
+	if err != nil {
+		log.Printf("worker: race day %q for %s: %v", day, guildID, err)
+		return
+	}
+	players, err := w.store.LatestRanks(guildID, riotapi.QueueSolo)
+	if err != nil {
+		log.Printf("worker: ranks for %s: %v", guildID, err)
+		return
+	}
+	start := date.Add(raceResetHour * time.Hour)
+	if err := w.store.AttachLPDeltas(players, riotapi.QueueSolo, start); err != nil {
+		log.Printf("worker: lp deltas for %s: %v", guildID, err)
+		return
+	}
+	var racers []storage.RankedPlayer
+	for _, p := range players {
+		if p.LPDeltaKnown {
+			racers = append(racers, p)
+		}
+	}
+	text := "🏁 **LPレース最終結果** " + date.Format("1/2") + "\nレース中の順位変動はありませんでした。"
+	if len(racers) > 0 {
+		sort.SliceStable(racers, func(i, j int) bool { return racers[i].LPDelta > racers[j].LPDelta })
+		var b strings.Builder
+		b.WriteString("🏁 **LPレース最終結果** " + date.Format("1/2") + "\n```\n")
+		for i, p := range racers {
+			fmt.Fprintf(&b, "%2d. %-24s %+dLP (%s)\n",
+				i+1, p.RiotID(), p.LPDelta, rank.Format(p.Tier, p.Division, p.LP))
+		}
+		b.WriteString("```")
+		text = b.String()
+	}
+	if err := w.post(threadID, text); err != nil {
+		log.Printf("worker: race standings to %s: %v", guildID, err)
+	}
+	archived := true
+	if _, err := w.discord.ChannelEdit(threadID, &discordgo.ChannelEdit{Archived: &archived}); err != nil {
+		log.Printf("worker: archive race thread for %s: %v", guildID, err)
+	}
+}
+
+// postRaceUpdate relays one player's rank change to the guild's live
+// race thread, if one is open. Called from the refresh cycle, so every
+// snapshot that moved a racer's LP shows up within the refresh cadence.
+func (w *Worker) postRaceUpdate(p *storage.Player, prev *storage.Snapshot, e *riotapi.LeagueEntryDTO) {
+	stored, err := w.store.GuildSetting(p.GuildID, storage.SettingRaceThread, "")
+	if err != nil {
+		log.Printf("worker: race thread setting for %s: %v", p.GuildID, err)
+		return
+	}
+	day, threadID, ok := strings.Cut(stored, ":")
+	if !ok || day != raceResetDay(time.Now().In(render.JST)).Format("20060102") {
+		return
+	}
+	delta := rank.Value(e.Tier, e.Rank, e.LeaguePoints) - rank.Value(prev.Tier, prev.Division, prev.LP)
+	if delta == 0 {
+		return
+	}
+	emoji := "📈"
+	if delta < 0 {
+		emoji = "📉"
+	}
+	msg := fmt.Sprintf("%s **%s** %s → %s (%+dLP相当)", emoji, p.RiotID(),
+		rank.Format(prev.Tier, prev.Division, prev.LP), rank.Format(e.Tier, e.Rank, e.LeaguePoints), delta)
+	if _, err := w.discord.ChannelMessageSend(threadID, msg); err != nil {
+		log.Printf("worker: race update to %s: %v", p.GuildID, err)
+	}
+}
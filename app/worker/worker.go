@@ -0,0 +1,733 @@
+// Package worker is the background process: it refreshes rank snapshots,
+// backfills match history and posts the daily ranking. It talks to
+// Discord over REST only — the gateway process owns the realtime
+// connection — so a slow Riot API can never stall the heartbeat.
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/keny-N/lol-ranking/app/config"
+	"github.com/keny-N/lol-ranking/app/i18n"
+	"github.com/keny-N/lol-ranking/app/rank"
+	"github.com/keny-N/lol-ranking/app/render"
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/sched"
+	"github.com/keny-N/lol-ranking/app/storage"
+	"github.com/keny-N/lol-ranking/app/web"
+	"github.com/keny-N/lol-ranking/app/xpost"
+)
+
+// matchBackfill is how many recent matches to pull per refresh.
+const matchBackfill = 20
+
+// Worker is the background-process application state.
+type Worker struct {
+	cfg     *config.Config
+	store   *storage.Store
+	riot    *riotapi.Client
+	discord *discordgo.Session // REST only, never opened
+	push    *web.Server        // nil unless HTTP_ADDR is set
+	x       *xpost.Client      // nil unless X_ACCESS_TOKEN is set
+
+	// guildClients caches per-guild-key Riot clients so every refresh
+	// against the same key shares one rate limiter.
+	guildClients struct {
+		sync.Mutex
+		m map[string]*riotapi.Client
+	}
+}
+
+// New wires up a Worker; call Run to start its loops.
+func New(cfg *config.Config, store *storage.Store) (*Worker, error) {
+	discord, err := discordgo.New("Bot " + cfg.DiscordToken)
+	if err != nil {
+		return nil, err
+	}
+	riot := riotapi.New(cfg.RiotAPIKey, cfg.Platform, cfg.Region)
+	if len(cfg.RegionFallbacks) > 0 {
+		riot = riot.WithRegionFallbacks(cfg.RegionFallbacks, func(from, to string) {
+			log.Printf("worker: riot regional failover: %s -> %s", from, to)
+		})
+	}
+	w := &Worker{
+		cfg:     cfg,
+		store:   store,
+		riot:    riot,
+		discord: discord,
+	}
+	if cfg.HTTPAddr != "" {
+		w.push = web.New(cfg.HTTPAddr, store, cfg.ShareSecret)
+	}
+	if cfg.XAccessToken != "" {
+		w.x = xpost.New(cfg.XAccessToken, cfg.XMilestoneTemplate)
+	}
+	return w, nil
+}
+
+// publish pushes an event to WebSocket subscribers, if the push server
+// is running.
+func (w *Worker) publish(eventType, guildID string, data interface{}) {
+	if w.push == nil {
+		return
+	}
+	w.push.Publish(web.Event{Type: eventType, GuildID: guildID, At: time.Now(), Data: data})
+}
+
+// unlessMaintenance wraps a scheduled job so operator-enabled
+// maintenance mode pauses it; the housekeeping job itself stays on.
+func (w *Worker) unlessMaintenance(fn func()) func() {
+	return func() {
+		mode, err := w.store.AppSetting(storage.AppSettingMaintenance, "off")
+		if err != nil {
+			log.Printf("worker: maintenance setting: %v", err)
+			return
+		}
+		if mode == "on" {
+			return
+		}
+		fn()
+	}
+}
+
+// defaultDailyPostCron is when the daily ranking goes out unless the
+// guild configures its own expression.
+const defaultDailyPostCron = "0 5 * * *"
+
+// defaultDailyRecapCron is when the previous day's recap goes out.
+const defaultDailyRecapCron = "15 5 * * *"
+
+// Run registers the periodic jobs, starts the scheduler and blocks
+// until stop is closed.
+func (w *Worker) Run(stop <-chan struct{}) error {
+	s := sched.New(render.JST)
+	jobs := []struct {
+		name, spec string
+		fn         func()
+	}{
+		{"refresh-snapshots", "* * * * *", w.unlessMaintenance(w.refreshDueGuilds)},
+		{"guild-schedules", "* * * * *", w.unlessMaintenance(w.runGuildSchedules)},
+		{"reminders", "* * * * *", w.unlessMaintenance(w.runReminders)},
+		{"maintenance", "30 4 * * *", w.runMaintenance},
+	}
+	for _, j := range jobs {
+		if err := s.Add(j.name, j.spec, j.fn); err != nil {
+			return err
+		}
+	}
+	s.Start()
+	defer s.Stop()
+	if w.push != nil {
+		w.push.Start()
+		defer w.push.Stop()
+	}
+
+	// The task queue is polled outside the scheduler: it is a mailbox
+	// from the gateway, not a timed job.
+	tasks := time.NewTicker(5 * time.Second)
+	defer tasks.Stop()
+
+	w.refreshAll()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-tasks.C:
+			w.drainTasks()
+		}
+	}
+}
+
+// runGuildSchedules fires each guild's cron-driven posts that are due
+// this minute. Expressions live in guild settings, so edits take effect
+// without restarting the worker.
+func (w *Worker) runGuildSchedules() {
+	now := time.Now().In(render.JST)
+	guildIDs, err := w.store.GuildIDs()
+	if err != nil {
+		log.Printf("worker: list guilds: %v", err)
+		return
+	}
+	for _, guildID := range guildIDs {
+		spec, err := w.store.GuildSetting(guildID, storage.SettingDailyPostCron, defaultDailyPostCron)
+		if err != nil {
+			log.Printf("worker: daily post cron for %s: %v", guildID, err)
+			continue
+		}
+		if sched.Due(spec, now) {
+			w.postDailyRanking(guildID)
+		}
+		recapSpec, err := w.store.GuildSetting(guildID, storage.SettingDailyRecapCron, defaultDailyRecapCron)
+		if err != nil {
+			log.Printf("worker: daily recap cron for %s: %v", guildID, err)
+			continue
+		}
+		if sched.Due(recapSpec, now) {
+			w.postDailyRecap(guildID, now.AddDate(0, 0, -1))
+		}
+		w.runRaceDay(guildID, now)
+	}
+}
+
+// runReminders DMs every user whose reminder cron matches this minute.
+func (w *Worker) runReminders() {
+	now := time.Now().In(render.JST)
+	reminders, err := w.store.AllReminders()
+	if err != nil {
+		log.Printf("worker: list reminders: %v", err)
+		return
+	}
+	for _, r := range reminders {
+		if !sched.Due(r.Cron, now) {
+			continue
+		}
+		dm, err := w.discord.UserChannelCreate(r.UserID)
+		if err != nil {
+			log.Printf("worker: open DM for reminder #%d: %v", r.ID, err)
+			continue
+		}
+		if _, err := w.discord.ChannelMessageSend(dm.ID, "⏰ リマインダー: "+r.Message); err != nil {
+			log.Printf("worker: send reminder #%d: %v", r.ID, err)
+		}
+	}
+}
+
+// postDailyRecap posts the previous day's per-player results, grouping
+// games registered players shared.
+func (w *Worker) postDailyRecap(guildID string, day time.Time) {
+	channelID, err := w.announceChannel(guildID, storage.SettingChannelRecap)
+	if err != nil {
+		log.Printf("worker: announce channel for %s: %v", guildID, err)
+		return
+	}
+	if channelID == "" {
+		return
+	}
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, render.JST)
+	entries, err := w.store.RecapData(guildID, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		log.Printf("worker: recap data for %s: %v", guildID, err)
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+	vision, err := w.store.GuildSetting(guildID, storage.SettingRecapVision, "on")
+	if err != nil {
+		log.Printf("worker: recap vision setting for %s: %v", guildID, err)
+		vision = "on"
+	}
+	if err := w.post(channelID, render.DailyRecap(from, entries, w.guildLocale(guildID), vision == "on", w.mentionFor(guildID))); err != nil {
+		log.Printf("worker: recap post to %s: %v", guildID, err)
+	}
+}
+
+// post sends content to a channel, chunking past Discord's length cap
+// so a big roster's recap or ranking never fails to deliver.
+func (w *Worker) post(channelID, content string) error {
+	for _, chunk := range render.SplitMessage(content) {
+		if _, err := w.discord.ChannelMessageSend(channelID, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mentionFor builds the mention resolver for one guild's announcements:
+// linked users are pinged unless they opted out with `!mentions off`.
+func (w *Worker) mentionFor(guildID string) func(discordUserID string) string {
+	return func(discordUserID string) string {
+		if discordUserID == "" {
+			return ""
+		}
+		pref, err := w.store.GuildSetting(guildID, storage.SettingMentionPrefix+discordUserID, "on")
+		if err != nil {
+			log.Printf("worker: mention preference for %s: %v", guildID, err)
+			return ""
+		}
+		if pref == "off" {
+			return ""
+		}
+		return "<@" + discordUserID + ">"
+	}
+}
+
+// guildLocale resolves a guild's display locale setting.
+func (w *Worker) guildLocale(guildID string) i18n.Locale {
+	stored, err := w.store.GuildSetting(guildID, storage.SettingLocale, "ja")
+	if err != nil {
+		log.Printf("worker: locale for %s: %v", guildID, err)
+		return i18n.Japanese
+	}
+	return i18n.Parse(stored)
+}
+
+// announceChannel resolves where posts of one announcement type go for
+// a guild, falling back to the guild default and then the process-wide
+// default channel.
+func (w *Worker) announceChannel(guildID, settingKey string) (string, error) {
+	channelID, err := w.store.AnnounceChannelFor(guildID, settingKey)
+	if err != nil {
+		return "", err
+	}
+	if channelID == "" {
+		channelID = w.cfg.AnnounceChannelID
+	}
+	return channelID, nil
+}
+
+// runMaintenance applies the retention policies to the match cache,
+// snapshot history and task queue.
+func (w *Worker) runMaintenance() {
+	now := time.Now()
+	matches, err := w.store.PruneMatches(now.AddDate(0, 0, -w.cfg.MatchRetentionDays))
+	if err != nil {
+		log.Printf("worker: prune matches: %v", err)
+	}
+	snapshots, err := w.store.PruneSnapshots(now.AddDate(0, 0, -w.cfg.SnapshotRetentionDays))
+	if err != nil {
+		log.Printf("worker: prune snapshots: %v", err)
+	}
+	tasks, err := w.store.PruneTasks(now.AddDate(0, 0, -7))
+	if err != nil {
+		log.Printf("worker: prune tasks: %v", err)
+	}
+	messages, err := w.store.PruneProcessedMessages(now.AddDate(0, 0, -1))
+	if err != nil {
+		log.Printf("worker: prune processed messages: %v", err)
+	}
+	states, err := w.store.PruneInteractionStates(now.AddDate(0, 0, -14))
+	if err != nil {
+		log.Printf("worker: prune interaction states: %v", err)
+	}
+	log.Printf("worker: maintenance pruned %d matches, %d snapshots, %d tasks, %d message ids, %d interaction states",
+		matches, snapshots, tasks, messages, states)
+}
+
+// refreshAll snapshots every registered player and backfills their
+// recent matches, regardless of stagger offsets. Used once at startup.
+func (w *Worker) refreshAll() {
+	players, err := w.store.AllPlayers()
+	if err != nil {
+		log.Printf("worker: list players: %v", err)
+		return
+	}
+	w.refreshPlayers(players)
+}
+
+// refreshDueGuilds refreshes the guilds whose stagger offset lands on
+// the current minute. Each guild still refreshes hourly, but offsets
+// spread the work across the hour in proportion to roster sizes, so a
+// many-guild deployment never fires all its API calls in one aligned
+// burst.
+func (w *Worker) refreshDueGuilds() {
+	players, err := w.store.AllPlayers()
+	if err != nil {
+		log.Printf("worker: list players: %v", err)
+		return
+	}
+	if len(players) == 0 {
+		return
+	}
+	// AllPlayers is ordered by ID, so guild order is stable across runs
+	// and offsets only shift when rosters change.
+	byGuild := map[string][]storage.Player{}
+	var guilds []string
+	for _, p := range players {
+		if _, seen := byGuild[p.GuildID]; !seen {
+			guilds = append(guilds, p.GuildID)
+		}
+		byGuild[p.GuildID] = append(byGuild[p.GuildID], p)
+	}
+	minute := time.Now().In(render.JST).Minute()
+	before := 0
+	refreshed := map[string]bool{}
+	for _, guildID := range guilds {
+		if before*60/len(players) == minute {
+			w.refreshPlayers(byGuild[guildID])
+			refreshed[guildID] = true
+		}
+		before += len(byGuild[guildID])
+	}
+	// High-priority players get their own faster cadence on top of the
+	// hourly stagger, skipping guilds this minute already covered.
+	if minute%highPriorityEvery == 0 {
+		var priority []storage.Player
+		for _, p := range players {
+			if p.Priority && !refreshed[p.GuildID] {
+				priority = append(priority, p)
+			}
+		}
+		if len(priority) > 0 {
+			w.refreshPlayers(priority)
+		}
+	}
+}
+
+// highPriorityEvery is the refresh cadence, in minutes, for players an
+// admin marked high priority with !priority.
+const highPriorityEvery = 10
+
+// refreshWorkers bounds how many player refreshes run in parallel. The
+// rate limiter still spaces the actual Riot requests, so the pool can't
+// blow the key's budget; it overlaps round-trips, decoding and storage
+// writes, which is what makes a 20+ player roster finish in seconds
+// once a production key drops the per-request spacing.
+const refreshWorkers = 4
+
+// refreshPlayers runs the refresh cycle over one batch of players.
+func (w *Worker) refreshPlayers(players []storage.Player) {
+	now := time.Now()
+	jobs := make(chan *storage.Player)
+	var wg sync.WaitGroup
+	for i := 0; i < refreshWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				err := w.refreshPlayer(p)
+				if err != nil {
+					log.Printf("worker: refresh %s: %v", p.RiotID(), err)
+				}
+				w.updateAccountState(p, err)
+			}
+		}()
+	}
+	for i := range players {
+		if players[i].IsPaused(now) {
+			continue // paused players don't spend API budget
+		}
+		jobs <- &players[i]
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// inactiveAfterDays is how long without a ranked game before an account
+// is flagged inactive.
+const inactiveAfterDays = 90
+
+// updateAccountState keeps the roster's health flags current: repeated
+// 404s mean the account moved, was banned or was renamed; a long match
+// drought means it went inactive. A clean refresh with recent games
+// clears any flag.
+func (w *Worker) updateAccountState(p *storage.Player, refreshErr error) {
+	state := ""
+	switch {
+	case errors.Is(refreshErr, riotapi.ErrNotFound):
+		state = storage.StateMissing
+	case refreshErr != nil:
+		return // transient errors say nothing about the account
+	default:
+		matches, err := w.store.RecentMatchesForPlayer(p.ID, 1)
+		if err != nil {
+			log.Printf("worker: last match for %s: %v", p.RiotID(), err)
+			return
+		}
+		if len(matches) > 0 &&
+			time.Since(time.Unix(matches[0].Info.GameEndTimestamp/1000, 0)) > inactiveAfterDays*24*time.Hour {
+			state = storage.StateInactive
+		}
+	}
+	if state == p.State {
+		return
+	}
+	if err := w.store.SetPlayerState(p.ID, state); err != nil {
+		log.Printf("worker: set state for %s: %v", p.RiotID(), err)
+	}
+}
+
+// riotFor returns the Riot client a guild's refreshes should use:
+// guilds that configured their own API key spend their own rate budget,
+// everyone else shares the default key.
+func (w *Worker) riotFor(guildID string) *riotapi.Client {
+	key, err := w.store.GuildSetting(guildID, storage.SettingRiotAPIKey, "")
+	if err != nil {
+		log.Printf("worker: api key setting for %s: %v", guildID, err)
+		return w.riot
+	}
+	if key == "" {
+		return w.riot
+	}
+	w.guildClients.Lock()
+	defer w.guildClients.Unlock()
+	if w.guildClients.m == nil {
+		w.guildClients.m = map[string]*riotapi.Client{}
+	}
+	c, ok := w.guildClients.m[key]
+	if !ok {
+		c = w.riot.WithAPIKey(key)
+		w.guildClients.m[key] = c
+	}
+	return c
+}
+
+// refreshPlayer fetches league entries and recent matches for one
+// player, routed to the platform the account lives on.
+func (w *Worker) refreshPlayer(p *storage.Player) error {
+	riot := w.riotFor(p.GuildID).ForPlatform(p.Platform)
+	entries, err := riot.LeagueEntriesBySummonerID(p.SummonerID)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		var prev *storage.Snapshot
+		if e.QueueType == riotapi.QueueSolo {
+			if prev, err = w.store.LatestSnapshot(p.ID, e.QueueType); err != nil {
+				return err
+			}
+		}
+		if err := w.store.SaveSnapshot(&storage.Snapshot{
+			PlayerID: p.ID, Queue: e.QueueType,
+			Tier: e.Tier, Division: e.Rank, LP: e.LeaguePoints,
+			Wins: e.Wins, Losses: e.Losses,
+		}); err != nil {
+			return err
+		}
+		if e.QueueType == riotapi.QueueSolo {
+			w.publish("rank_refreshed", p.GuildID, map[string]interface{}{
+				"player": p.RiotID(),
+				"tier":   e.Tier, "division": e.Rank, "lp": e.LeaguePoints,
+			})
+			if prev != nil {
+				w.postRaceUpdate(p, prev, &e)
+			}
+			if prev != nil && rank.Value(e.Tier, e.Rank, e.LeaguePoints) > rank.Value(prev.Tier, prev.Division, prev.LP) &&
+				(e.Tier != prev.Tier || e.Rank != prev.Division) {
+				w.publish("promotion", p.GuildID, map[string]interface{}{
+					"player": p.RiotID(),
+					"from":   rank.Format(prev.Tier, prev.Division, prev.LP),
+					"to":     rank.Format(e.Tier, e.Rank, e.LeaguePoints),
+				})
+				if e.Tier != prev.Tier {
+					w.tweetMilestone(p, &e)
+				}
+			}
+			w.checkGoal(p, &e)
+		}
+	}
+
+	matchIDs, err := riot.MatchIDsByPUUID(p.PUUID, 0, 0, matchBackfill)
+	if err != nil {
+		return err
+	}
+	for _, id := range matchIDs {
+		cached, err := w.store.HasMatch(p.ID, id)
+		if err != nil {
+			return err
+		}
+		if cached {
+			continue
+		}
+		m, err := riot.Match(id)
+		if err != nil {
+			return err
+		}
+		if err := w.store.SaveMatch(p.ID, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// milestoneTier is the first tier whose reach is worth a tweet.
+const milestoneTier = "DIAMOND"
+
+// tweetMilestone posts a community-account tweet when a player enters a
+// milestone tier. Tweet failures are logged, never fatal: the refresh
+// matters more than the announcement.
+func (w *Worker) tweetMilestone(p *storage.Player, e *riotapi.LeagueEntryDTO) {
+	if w.x == nil {
+		return
+	}
+	if rank.Value(e.Tier, "IV", 0) < rank.Value(milestoneTier, "IV", 0) {
+		return
+	}
+	text := w.x.Milestone(p.RiotID(), rank.Format(e.Tier, e.Rank, e.LeaguePoints))
+	if err := w.x.Post(text); err != nil {
+		log.Printf("worker: tweet milestone for %s: %v", p.RiotID(), err)
+	}
+}
+
+// checkGoal celebrates a player's season goal the first time a refresh
+// sees it reached.
+func (w *Worker) checkGoal(p *storage.Player, e *riotapi.LeagueEntryDTO) {
+	season := storage.CurrentSeason()
+	goal, err := w.store.GoalFor(p.ID, season)
+	if err != nil {
+		log.Printf("worker: goal for %s: %v", p.RiotID(), err)
+		return
+	}
+	if goal == nil || !goal.AchievedAt.IsZero() {
+		return
+	}
+	if rank.Value(e.Tier, e.Rank, e.LeaguePoints) < rank.Value(goal.Tier, goal.Division, 0) {
+		return
+	}
+	if err := w.store.MarkGoalAchieved(p.ID, season); err != nil {
+		log.Printf("worker: mark goal for %s: %v", p.RiotID(), err)
+		return
+	}
+	channelID, err := w.announceChannel(p.GuildID, storage.SettingChannelPromotions)
+	if err != nil {
+		log.Printf("worker: announce channel for %s: %v", p.GuildID, err)
+		return
+	}
+	if channelID == "" {
+		return
+	}
+	w.publish("goal_achieved", p.GuildID, map[string]interface{}{
+		"player": p.RiotID(), "tier": goal.Tier, "division": goal.Division,
+	})
+	name := p.RiotID()
+	if m := w.mentionFor(p.GuildID)(p.DiscordUserID); m != "" {
+		name += " (" + m + ")"
+	}
+	msg := fmt.Sprintf("🎉 **%s** が今シーズンの目標 **%s %s** を達成しました！おめでとうございます！",
+		name, goal.Tier, goal.Division)
+	if _, err := w.discord.ChannelMessageSend(channelID, msg); err != nil {
+		log.Printf("worker: goal post to %s: %v", p.GuildID, err)
+	}
+}
+
+// drainTasks works through whatever the gateway has queued up.
+func (w *Worker) drainTasks() {
+	for {
+		task, err := w.store.NextTask()
+		if err != nil {
+			log.Printf("worker: next task: %v", err)
+			return
+		}
+		if task == nil {
+			return
+		}
+		if err := w.runTask(task); err != nil {
+			log.Printf("worker: task %d (%s): %v", task.ID, task.Kind, err)
+			if err := w.store.FailTask(task.ID); err != nil {
+				log.Printf("worker: fail task %d: %v", task.ID, err)
+			}
+			continue
+		}
+		if err := w.store.FinishTask(task.ID); err != nil {
+			log.Printf("worker: finish task %d: %v", task.ID, err)
+		}
+	}
+}
+
+func (w *Worker) runTask(task *storage.Task) error {
+	switch task.Kind {
+	case storage.TaskRefreshPlayer:
+		id, err := strconv.ParseInt(task.Payload, 10, 64)
+		if err != nil {
+			return err
+		}
+		players, err := w.store.AllPlayers()
+		if err != nil {
+			return err
+		}
+		for i := range players {
+			if players[i].ID == id {
+				return w.refreshPlayer(&players[i])
+			}
+		}
+		return nil // player was removed in the meantime
+	case storage.TaskPostDaily:
+		w.postDailyRanking(task.Payload)
+		return nil
+	case storage.TaskPostRecap:
+		w.postDailyRecap(task.Payload, time.Now().In(render.JST).AddDate(0, 0, -1))
+		return nil
+	case storage.TaskFlexPing:
+		return w.postFlexPing(task.Payload)
+	default:
+		log.Printf("worker: unknown task kind %q", task.Kind)
+		return nil
+	}
+}
+
+// postFlexPing pings a flex-night poll's participants at the planned
+// time. The poll state is re-read from the message's interaction state
+// so late joins and dropouts count; a poll whose state is gone (pruned
+// or never reached) is silently dropped.
+func (w *Worker) postFlexPing(payload string) error {
+	var ref struct {
+		MessageID string `json:"message_id"`
+		ChannelID string `json:"channel_id"`
+	}
+	if err := json.Unmarshal([]byte(payload), &ref); err != nil {
+		return err
+	}
+	state, err := w.store.InteractionState(ref.MessageID)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return nil
+	}
+	var fs struct {
+		Users []string `json:"users"`
+	}
+	if err := json.Unmarshal([]byte(state.Payload), &fs); err != nil {
+		return err
+	}
+	if err := w.store.DeleteInteractionState(ref.MessageID); err != nil {
+		log.Printf("worker: drop flex poll state %s: %v", ref.MessageID, err)
+	}
+	if len(fs.Users) == 0 {
+		return nil
+	}
+	mentions := make([]string, 0, len(fs.Users))
+	for _, id := range fs.Users {
+		mentions = append(mentions, "<@"+id+">")
+	}
+	_, err = w.discord.ChannelMessageSend(ref.ChannelID,
+		"🎮 フレックスナイトの時間です！ "+strings.Join(mentions, " "))
+	return err
+}
+
+// postDailyRanking posts the current ranking to one guild's
+// announcement channel.
+func (w *Worker) postDailyRanking(guildID string) {
+	channelID, err := w.announceChannel(guildID, storage.SettingChannelDaily)
+	if err != nil {
+		log.Printf("worker: announce channel for %s: %v", guildID, err)
+		return
+	}
+	if channelID == "" {
+		return
+	}
+	players, err := w.store.LatestRanks(guildID, riotapi.QueueSolo)
+	if err != nil {
+		log.Printf("worker: ranks for %s: %v", guildID, err)
+		return
+	}
+	if len(players) == 0 {
+		return
+	}
+	if err := w.store.AttachTrends(players); err != nil {
+		log.Printf("worker: trends for %s: %v", guildID, err)
+	}
+	now := time.Now().In(render.JST)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, render.JST)
+	if err := w.store.AttachLPDeltas(players, riotapi.QueueSolo, dayStart); err != nil {
+		log.Printf("worker: lp deltas for %s: %v", guildID, err)
+	}
+	stored, err := w.store.GuildSetting(guildID, storage.SettingOutputFormat, "detailed")
+	if err != nil {
+		log.Printf("worker: output format for %s: %v", guildID, err)
+		return
+	}
+	format, _ := render.ParseFormat(stored)
+	if err := w.post(channelID, render.Ranking(players, render.SoloRankingTitle, format, w.guildLocale(guildID))); err != nil {
+		log.Printf("worker: daily post to %s: %v", guildID, err)
+	}
+}
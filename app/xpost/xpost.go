@@ -0,0 +1,66 @@
+// Package xpost publishes milestone tweets to X (Twitter) for groups
+// that run a community account. It is optional: without an access token
+// the worker simply never posts.
+package xpost
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// tweetEndpoint is the X API v2 tweet-creation endpoint.
+const tweetEndpoint = "https://api.twitter.com/2/tweets"
+
+// Client posts tweets with an OAuth2 user access token.
+type Client struct {
+	token      string
+	template   string
+	httpClient *http.Client
+}
+
+// New builds a Client. template may use the placeholders {player} and
+// {rank}; an empty template falls back to the default.
+func New(token, template string) *Client {
+	if template == "" {
+		template = "🎉 {player} が {rank} に到達しました！ #LoL"
+	}
+	return &Client{
+		token:      token,
+		template:   template,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Milestone renders the configured template for one milestone.
+func (c *Client) Milestone(player, rank string) string {
+	return strings.NewReplacer("{player}", player, "{rank}", rank).Replace(c.template)
+}
+
+// Post publishes one tweet.
+func (c *Client) Post(text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, tweetEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("x api: status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// The gateway process keeps the Discord connection alive and answers
+// commands. Run one of these per bot token; pair it with a worker
+// process pointed at the same database.
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/keny-N/lol-ranking/app/bot"
+	"github.com/keny-N/lol-ranking/app/config"
+	"github.com/keny-N/lol-ranking/app/logging"
+	"github.com/keny-N/lol-ranking/app/secrets"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("gateway: %v", err)
+	}
+	log.SetOutput(logging.NewRedactor(os.Stderr, cfg.DiscordToken, cfg.RiotAPIKey))
+
+	store, err := storage.Open(cfg.DBPath)
+	if err != nil {
+		log.Fatalf("gateway: open storage: %v", err)
+	}
+	defer store.Close()
+	if cfg.SecretKey != "" {
+		box, err := secrets.New(cfg.SecretKey)
+		if err != nil {
+			log.Fatalf("gateway: %v", err)
+		}
+		store.UseSecrets(box)
+	}
+
+	b, err := bot.New(cfg, store)
+	if err != nil {
+		log.Fatalf("gateway: %v", err)
+	}
+	if err := b.Start(); err != nil {
+		log.Fatalf("gateway: %v", err)
+	}
+	defer b.Stop()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	log.Println("gateway: shutting down")
+}
@@ -0,0 +1,147 @@
+// The loadtest command validates the refresh pipeline under load
+// without touching Discord or Riot: it simulates N guilds of M players
+// against a local fixture Riot server and drives league lookups plus
+// snapshot writes through the real riotapi client and storage layer,
+// then reports throughput, rate-limiter behavior and memory usage.
+//
+//	go run ./cmd/loadtest -guilds 20 -players 10 -rounds 3
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/keny-N/lol-ranking/app/riotapi"
+	"github.com/keny-N/lol-ranking/app/storage"
+)
+
+func main() {
+	guilds := flag.Int("guilds", 10, "simulated guild count")
+	players := flag.Int("players", 10, "players per guild")
+	workers := flag.Int("workers", 4, "concurrent refresh workers (matches the worker pool)")
+	rounds := flag.Int("rounds", 1, "full refresh passes over the roster")
+	rate := flag.String("rate", "100:1", "X-App-Rate-Limit the fixture advertises")
+	flag.Parse()
+
+	var served atomic.Int64
+	fixture := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served.Add(1)
+		count := served.Load()
+		w.Header().Set("X-App-Rate-Limit", *rate)
+		w.Header().Set("X-App-Rate-Limit-Count", fmt.Sprintf("%d:1", count%97))
+		json.NewEncoder(w).Encode([]riotapi.LeagueEntryDTO{{
+			QueueType: riotapi.QueueSolo,
+			Tier:      "GOLD", Rank: "II",
+			LeaguePoints: int(count % 100),
+			Wins:         40, Losses: 38,
+		}})
+	}))
+	defer fixture.Close()
+
+	dir, err := os.MkdirTemp("", "lol-ranking-loadtest")
+	if err != nil {
+		log.Fatalf("loadtest: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := storage.Open(filepath.Join(dir, "loadtest.db"))
+	if err != nil {
+		log.Fatalf("loadtest: open storage: %v", err)
+	}
+	defer store.Close()
+
+	// Seed the simulated rosters.
+	roster := make([]storage.Player, 0, *guilds**players)
+	for g := 0; g < *guilds; g++ {
+		guildID := fmt.Sprintf("guild-%d", g)
+		if err := store.EnsureGuild(guildID); err != nil {
+			log.Fatalf("loadtest: seed guild: %v", err)
+		}
+		for p := 0; p < *players; p++ {
+			player := storage.Player{
+				GuildID:    guildID,
+				GameName:   fmt.Sprintf("Player%d", p),
+				TagLine:    fmt.Sprintf("G%d", g),
+				PUUID:      fmt.Sprintf("puuid-%d-%d", g, p),
+				SummonerID: fmt.Sprintf("summoner-%d-%d", g, p),
+				Platform:   "jp1",
+			}
+			if _, err := store.AddPlayer(&player); err != nil {
+				log.Fatalf("loadtest: seed player: %v", err)
+			}
+			roster = append(roster, player)
+		}
+	}
+
+	riot := riotapi.NewWithBaseURLs("loadtest-key", fixture.URL, fixture.URL)
+	var refreshed, failed atomic.Int64
+	var slowest atomic.Int64
+
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for round := 0; round < *rounds; round++ {
+		jobs := make(chan storage.Player)
+		var wg sync.WaitGroup
+		for i := 0; i < *workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for p := range jobs {
+					callStart := time.Now()
+					entries, err := riot.LeagueEntriesBySummonerID(p.SummonerID)
+					if d := time.Since(callStart); d.Nanoseconds() > slowest.Load() {
+						slowest.Store(d.Nanoseconds())
+					}
+					if err != nil {
+						failed.Add(1)
+						continue
+					}
+					for _, e := range entries {
+						if err := store.SaveSnapshot(&storage.Snapshot{
+							PlayerID: p.ID, Queue: e.QueueType,
+							Tier: e.Tier, Division: e.Rank, LP: e.LeaguePoints,
+							Wins: e.Wins, Losses: e.Losses,
+						}); err != nil {
+							failed.Add(1)
+						}
+					}
+					refreshed.Add(1)
+				}
+			}()
+		}
+		for _, p := range roster {
+			jobs <- p
+		}
+		close(jobs)
+		wg.Wait()
+	}
+
+	elapsed := time.Since(start)
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	fmt.Printf("roster:      %d guilds × %d players, %d workers, %d round(s)\n",
+		*guilds, *players, *workers, *rounds)
+	fmt.Printf("refreshed:   %d players in %s (%.1f/s), %d failed\n",
+		refreshed.Load(), elapsed.Round(time.Millisecond),
+		float64(refreshed.Load())/elapsed.Seconds(), failed.Load())
+	fmt.Printf("rate limit:  fixture advertised %s, served %d requests, slowest call %s\n",
+		*rate, served.Load(), time.Duration(slowest.Load()).Round(time.Millisecond))
+	fmt.Printf("memory:      heap %0.1f MiB (Δ %+0.1f MiB), %0.1f MiB allocated, %d GC cycles\n",
+		float64(after.HeapAlloc)/(1<<20),
+		(float64(after.HeapAlloc)-float64(before.HeapAlloc))/(1<<20),
+		float64(after.TotalAlloc-before.TotalAlloc)/(1<<20),
+		after.NumGC-before.NumGC)
+}
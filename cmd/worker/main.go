@@ -0,0 +1,55 @@
+// The worker process fetches Riot data, takes rank snapshots and posts
+// scheduled messages. It shares a database with the gateway process and
+// uses Discord over REST only.
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/keny-N/lol-ranking/app/config"
+	"github.com/keny-N/lol-ranking/app/logging"
+	"github.com/keny-N/lol-ranking/app/secrets"
+	"github.com/keny-N/lol-ranking/app/storage"
+	"github.com/keny-N/lol-ranking/app/worker"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("worker: %v", err)
+	}
+	log.SetOutput(logging.NewRedactor(os.Stderr, cfg.DiscordToken, cfg.RiotAPIKey))
+
+	store, err := storage.Open(cfg.DBPath)
+	if err != nil {
+		log.Fatalf("worker: open storage: %v", err)
+	}
+	defer store.Close()
+	if cfg.SecretKey != "" {
+		box, err := secrets.New(cfg.SecretKey)
+		if err != nil {
+			log.Fatalf("worker: %v", err)
+		}
+		store.UseSecrets(box)
+	}
+
+	w, err := worker.New(cfg, store)
+	if err != nil {
+		log.Fatalf("worker: %v", err)
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		log.Println("worker: shutting down")
+		close(stop)
+	}()
+	if err := w.Run(stop); err != nil {
+		log.Fatalf("worker: %v", err)
+	}
+}